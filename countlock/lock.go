@@ -16,73 +16,155 @@
 
 // Package countlock is useful for synchronizing multiple go routines, when
 // one of them has to take a lead. Up() method increments an internal
-// counter. Down() method decrements, but blocks and waits when it
-// reaches zero. One can call Down() only as many times Up() is called.
+// counter. Down() method decrements, but blocks and waits while the
+// counter is too low. Both take a weight, the lock is a weighted counting
+// semaphore which starts empty.
+//
+// Waiters are served in FIFO order, a large Down() is not starved by
+// smaller ones arriving later.  No goroutine is used.
 package countlock
 
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed - Down() called on or waiting for a closed lock.
+var ErrClosed = errors.New("countlock: lock closed")
+
 // Locker defines a counting lock
 type Locker interface {
-	Up()    // Increment the counter.
-	Down()  // Decrement the counter. When it reaches zero, block and wait.
-	Close() // Release all resources and return.
+	Up(n int64)                              // Increment the counter by n.
+	Down(ctx context.Context, n int64) error // Decrement the counter by n, block and wait while it is lower than n.
+	TryDown(n int64) bool                    // Decrement the counter by n if possible without waiting.
+	Level() int64                            // Current value of the counter.
+	Close()                                  // Fail all waiting and future Down calls.
 }
 
 // New returns a newly initialized counter lock object.
 func New() Locker {
-	upCh := make(chan bool)
-	downCh := make(chan bool)
-	closeCh := make(chan bool)
-
-	go func(upCh, downCh, closeCh chan bool) {
-		defer close(closeCh)
-		defer close(downCh)
-		defer close(upCh)
+	return &lock{}
+}
 
-		var level int64 // Keeps track of Up and Down count.
-		for {
+// waiter is a Down call waiting in the queue.
+type waiter struct {
+	n     int64
+	err   error         // result of Down, set before ready is closed
+	ready chan struct{} // closed when the waiter was served
+}
 
-			if level == 0 {
-				// Up() has neven been called or may
-				// be Down() has been called equal number
-				// of times.
+type lock struct {
+	mu      sync.Mutex
+	level   int64
+	waiters list.List
+	closed  bool
+}
 
-				select {
-				case <-upCh:
-					level++
-				case <-closeCh: // We are done.
-					return
-				}
-			}
+func (l *lock) Up(n int64) {
+	if n < 0 {
+		panic("countlock: negative Up")
+	}
+	l.mu.Lock()
+	l.level += n
+	l.notify()
+	l.mu.Unlock()
+}
 
-			// Up is called at least once. It is safe to also listen on down.
-			select {
-			case <-upCh:
-				level++
-			case <-downCh:
-				level--
-			case <-closeCh:
-				return
-			}
+// notify serves waiters in order as long as the level allows it.
+// Must be called with l.mu held.
+func (l *lock) notify() {
+	for {
+		front := l.waiters.Front()
+		if front == nil {
+			return
 		}
-	}(upCh, downCh, closeCh)
-
-	return &lock{upCh: upCh, downCh: downCh, closeCh: closeCh}
+		w := front.Value.(*waiter)
+		if w.n > l.level {
+			// Later waiters must not overtake the first one.
+			return
+		}
+		l.level -= w.n
+		l.waiters.Remove(front)
+		close(w.ready)
+	}
 }
 
-type lock struct {
-	upCh    chan bool
-	downCh  chan bool
-	closeCh chan bool
+func (l *lock) Down(ctx context.Context, n int64) error {
+	if n < 0 {
+		panic("countlock: negative Down")
+	}
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return ErrClosed
+	}
+	if l.waiters.Len() == 0 && n <= l.level {
+		l.level -= n
+		l.mu.Unlock()
+		return nil
+	}
+	w := &waiter{n: n, ready: make(chan struct{})}
+	elem := l.waiters.PushBack(w)
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return w.err
+	case <-ctx.Done():
+		l.mu.Lock()
+		select {
+		case <-w.ready:
+			// Served concurrently with the cancellation, give the
+			// count back as the caller sees an error.
+			if w.err == nil {
+				l.level += n
+				l.notify()
+			}
+		default:
+			isFront := l.waiters.Front() == elem
+			l.waiters.Remove(elem)
+			// Waiters behind a large cancelled one may fit now.
+			if isFront {
+				l.notify()
+			}
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	}
 }
 
-func (l lock) Up() {
-	l.upCh <- true
+func (l *lock) TryDown(n int64) bool {
+	if n < 0 {
+		panic("countlock: negative TryDown")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed || l.waiters.Len() > 0 || n > l.level {
+		return false
+	}
+	l.level -= n
+	return true
 }
 
-func (l lock) Down() {
-	l.downCh <- true
+func (l *lock) Level() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.level
 }
 
-func (l lock) Close() {
-	l.closeCh <- true
+func (l *lock) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+	l.closed = true
+	for e := l.waiters.Front(); e != nil; e = e.Next() {
+		w := e.Value.(*waiter)
+		w.err = ErrClosed
+		close(w.ready)
+	}
+	l.waiters.Init()
 }
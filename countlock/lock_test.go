@@ -19,7 +19,11 @@
 package countlock
 
 import (
+	"context"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	. "github.com/minio/check"
 )
@@ -31,14 +35,147 @@ type MySuite struct{}
 var _ = Suite(&MySuite{})
 
 func (s *MySuite) TestCountLock(c *C) {
+	ctx := context.Background()
 	lock := New()
 	defer lock.Close()
-	lock.Up()
-	lock.Up()
-	lock.Down()
-	lock.Down()
-	lock.Up()
-	lock.Down()
-	lock.Up()
-	lock.Down()
+	lock.Up(1)
+	lock.Up(1)
+	c.Assert(lock.Down(ctx, 1), IsNil)
+	c.Assert(lock.Down(ctx, 1), IsNil)
+	lock.Up(1)
+	c.Assert(lock.Down(ctx, 1), IsNil)
+	lock.Up(5)
+	c.Assert(lock.Level(), Equals, int64(5))
+	c.Assert(lock.Down(ctx, 3), IsNil)
+	c.Assert(lock.TryDown(3), Equals, false)
+	c.Assert(lock.TryDown(2), Equals, true)
+	c.Assert(lock.Level(), Equals, int64(0))
+	c.Assert(lock.TryDown(0), Equals, true)
+}
+
+// waitQueued waits until n Down calls are queued on l.
+func waitQueued(l Locker, n int) {
+	for queued(l) < n {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// queued returns the number of Down calls waiting on l.
+func queued(l Locker) int {
+	lk := l.(*lock)
+	lk.mu.Lock()
+	defer lk.mu.Unlock()
+	return lk.waiters.Len()
+}
+
+func (s *MySuite) TestCountLockFIFO(c *C) {
+	lock := New()
+	defer lock.Close()
+
+	var wg sync.WaitGroup
+	weights := []int64{3, 1, 2, 1}
+	for i, n := range weights {
+		wg.Add(1)
+		go func(n int64) {
+			defer wg.Done()
+			c.Check(lock.Down(context.Background(), n), IsNil)
+		}(n)
+		waitQueued(lock, i+1)
+	}
+
+	// The first waiter needs 3, the smaller ones must not overtake it.
+	lock.Up(2)
+	c.Assert(queued(lock), Equals, 4)
+	c.Assert(lock.Level(), Equals, int64(2))
+	c.Assert(lock.TryDown(1), Equals, false)
+	lock.Up(1)
+	c.Assert(queued(lock), Equals, 3)
+	c.Assert(lock.Level(), Equals, int64(0))
+	// The second and third waiter fit, the fourth does not.
+	lock.Up(3)
+	c.Assert(queued(lock), Equals, 1)
+	lock.Up(1)
+	wg.Wait()
+	c.Assert(queued(lock), Equals, 0)
+	c.Assert(lock.Level(), Equals, int64(0))
+}
+
+func (s *MySuite) TestCountLockCancel(c *C) {
+	lock := New()
+	defer lock.Close()
+
+	// A cancelled large waiter unblocks the small one behind it.
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error)
+	go func() { errCh <- lock.Down(ctx, 10) }()
+	waitQueued(lock, 1)
+	go func() { errCh <- lock.Down(context.Background(), 1) }()
+	waitQueued(lock, 2)
+	lock.Up(1)
+	cancel()
+	c.Assert(<-errCh, Equals, context.Canceled)
+	c.Assert(<-errCh, IsNil)
+	c.Assert(lock.Level(), Equals, int64(0))
+
+	// Deadline while waiting.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	c.Assert(lock.Down(ctx, 1), Equals, context.DeadlineExceeded)
+	c.Assert(lock.Level(), Equals, int64(0))
+}
+
+func (s *MySuite) TestCountLockClose(c *C) {
+	lock := New()
+	errCh := make(chan error)
+	go func() { errCh <- lock.Down(context.Background(), 1) }()
+	waitQueued(lock, 1)
+	lock.Close()
+	c.Assert(<-errCh, Equals, ErrClosed)
+	c.Assert(lock.Down(context.Background(), 1), Equals, ErrClosed)
+	c.Assert(lock.TryDown(0), Equals, false)
+	lock.Close()
+}
+
+// Many goroutines racing Up, Down, TryDown and cancellation must never
+// lose or create counts.
+func (s *MySuite) TestCountLockRace(c *C) {
+	const workers, rounds = 16, 200
+	lock := New()
+	defer lock.Close()
+
+	var taken int64
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < rounds; j++ {
+				n := int64(j%3 + 1)
+				lock.Up(n)
+				switch j % 4 {
+				case 0:
+					if lock.TryDown(n) {
+						atomic.AddInt64(&taken, n)
+					}
+				case 1:
+					ctx, cancel := context.WithTimeout(context.Background(), time.Microsecond)
+					if lock.Down(ctx, n) == nil {
+						atomic.AddInt64(&taken, n)
+					}
+					cancel()
+				default:
+					if lock.Down(context.Background(), n) == nil {
+						atomic.AddInt64(&taken, n)
+					}
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var total int64
+	for j := 0; j < rounds; j++ {
+		total += int64(j%3+1) * workers
+	}
+	c.Assert(taken+lock.Level(), Equals, total)
 }
@@ -0,0 +1,218 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package multihash computes several digests of the same stream in a
+// single pass, each algorithm running in its own goroutine.
+//
+// Algorithms are given as hash.Hash constructors, for example:
+//
+//	h := multihash.New(md5.New, sha256.New)
+//	if _, err := io.Copy(h, body); err != nil {
+//		return err
+//	}
+//	sums := h.Sums() // sums[0] is md5, sums[1] is sha256
+//
+// Either Sums or Close must be called on every Writer, even when writing
+// fails, otherwise its goroutines leak.
+package multihash
+
+import (
+	"errors"
+	"hash"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// bufferSize is the size of the chunks handed over to the
+	// per algorithm goroutines.
+	bufferSize = 128 * 1024
+
+	// pipelineDepth is the number of chunks which can be queued
+	// for each algorithm before Write blocks.
+	pipelineDepth = 4
+)
+
+var (
+	// ErrSummed - write attempted after Sums was called.
+	ErrSummed = errors.New("multihash: write after Sums")
+	// ErrClosed - write attempted after Close was called.
+	ErrClosed = errors.New("multihash: write after Close")
+)
+
+// chunk is a piece of the stream shared read-only by all algorithms,
+// it returns to the pool once every algorithm has consumed it.
+type chunk struct {
+	data []byte
+	refs int32
+}
+
+// Writer computes the digests of everything written to it.  It runs a
+// goroutine per algorithm until Sums or Close is called.
+type Writer struct {
+	hashes []hash.Hash
+	queues []chan *chunk
+	wg     sync.WaitGroup
+	pool   sync.Pool
+	sums   [][]byte
+	closed bool
+}
+
+// New returns a Writer computing one digest per constructor in algs.
+func New(algs ...func() hash.Hash) *Writer {
+	w := &Writer{
+		hashes: make([]hash.Hash, len(algs)),
+		queues: make([]chan *chunk, len(algs)),
+	}
+	w.pool.New = func() interface{} {
+		return &chunk{data: make([]byte, 0, bufferSize)}
+	}
+	for i, alg := range algs {
+		w.hashes[i] = alg()
+		w.queues[i] = make(chan *chunk, pipelineDepth)
+		w.wg.Add(1)
+		go w.consume(w.hashes[i], w.queues[i])
+	}
+	return w
+}
+
+// consume feeds every chunk from queue into h until queue is closed.
+func (w *Writer) consume(h hash.Hash, queue <-chan *chunk) {
+	defer w.wg.Done()
+	for c := range queue {
+		h.Write(c.data)
+		if atomic.AddInt32(&c.refs, -1) == 0 {
+			c.data = c.data[:0]
+			w.pool.Put(c)
+		}
+	}
+}
+
+// check returns an error if no more data can be written.
+func (w *Writer) check() error {
+	if w.sums != nil {
+		return ErrSummed
+	}
+	if w.closed {
+		return ErrClosed
+	}
+	return nil
+}
+
+// dispatch hands a filled chunk over to all algorithms.
+func (w *Writer) dispatch(c *chunk) {
+	if len(w.queues) == 0 {
+		c.data = c.data[:0]
+		w.pool.Put(c)
+		return
+	}
+	c.refs = int32(len(w.queues))
+	for _, queue := range w.queues {
+		queue <- c
+	}
+}
+
+// Write copies p and queues it for all algorithms, it only blocks when
+// the slowest algorithm falls behind by more than the pipeline depth.
+func (w *Writer) Write(p []byte) (n int, err error) {
+	if err = w.check(); err != nil {
+		return 0, err
+	}
+	for len(p) > 0 {
+		c := w.pool.Get().(*chunk)
+		m := copy(c.data[:cap(c.data)], p)
+		c.data = c.data[:m]
+		w.dispatch(c)
+		n += m
+		p = p[m:]
+	}
+	return n, nil
+}
+
+// ReadFrom reads r until io.EOF, reading directly into the chunks
+// handed over to the algorithms.
+func (w *Writer) ReadFrom(r io.Reader) (n int64, err error) {
+	if err = w.check(); err != nil {
+		return 0, err
+	}
+	for {
+		c := w.pool.Get().(*chunk)
+		m, rerr := io.ReadFull(r, c.data[:cap(c.data)])
+		c.data = c.data[:m]
+		n += int64(m)
+		if m > 0 {
+			w.dispatch(c)
+		} else {
+			w.pool.Put(c)
+		}
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			return n, nil
+		}
+		if rerr != nil {
+			return n, rerr
+		}
+	}
+}
+
+// Sums waits for all algorithms to finish and returns their digests in
+// the order the constructors were passed to New. No more data can be
+// written afterwards.
+func (w *Writer) Sums() [][]byte {
+	if w.sums != nil {
+		return w.sums
+	}
+	w.stop()
+	w.sums = make([][]byte, len(w.hashes))
+	for i, h := range w.hashes {
+		w.sums[i] = h.Sum(nil)
+	}
+	return w.sums
+}
+
+// Close stops the goroutines of the algorithms, for Writers whose
+// digests are not needed, for example after a failed write.  No more
+// data can be written afterwards, Sums still returns the digests of the
+// data written before Close.
+func (w *Writer) Close() error {
+	w.stop()
+	return nil
+}
+
+// stop closes the queues, once, and waits for all algorithms to consume
+// them.
+func (w *Writer) stop() {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	for _, queue := range w.queues {
+		close(queue)
+	}
+	w.wg.Wait()
+}
+
+// Sum reads r until io.EOF and returns the digests of all algorithms
+// along with the number of bytes read.
+func Sum(r io.Reader, algs ...func() hash.Hash) ([][]byte, int64, error) {
+	w := New(algs...)
+	n, err := w.ReadFrom(r)
+	sums := w.Sums()
+	if err != nil {
+		return nil, n, err
+	}
+	return sums, n, nil
+}
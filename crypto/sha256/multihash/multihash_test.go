@@ -0,0 +1,228 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multihash
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"testing"
+)
+
+var sizes = []int{0, 1, 63, 64, 1024, bufferSize - 1, bufferSize, bufferSize + 1, 5*bufferSize + 17}
+
+func randomData(t testing.TB, size int) []byte {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func sequentialSums(data []byte, algs ...func() hash.Hash) [][]byte {
+	var sums [][]byte
+	for _, alg := range algs {
+		h := alg()
+		h.Write(data)
+		sums = append(sums, h.Sum(nil))
+	}
+	return sums
+}
+
+// Tests that Write and ReadFrom match digests computed sequentially.
+func TestWriter(t *testing.T) {
+	algs := []func() hash.Hash{md5.New, sha1.New, sha256.New}
+	for _, size := range sizes {
+		data := randomData(t, size)
+		expected := sequentialSums(data, algs...)
+
+		// Small writes spanning several chunks.
+		w := New(algs...)
+		for p := data; len(p) > 0; {
+			n := 1000
+			if n > len(p) {
+				n = len(p)
+			}
+			if _, err := w.Write(p[:n]); err != nil {
+				t.Fatal(err)
+			}
+			p = p[n:]
+		}
+		for i, sum := range w.Sums() {
+			if !bytes.Equal(sum, expected[i]) {
+				t.Errorf("Write: size %d alg %d: got %x want %x", size, i, sum, expected[i])
+			}
+		}
+
+		sums, n, err := Sum(bytes.NewReader(data), algs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(size) {
+			t.Errorf("Sum: read %d bytes want %d", n, size)
+		}
+		for i, sum := range sums {
+			if !bytes.Equal(sum, expected[i]) {
+				t.Errorf("Sum: size %d alg %d: got %x want %x", size, i, sum, expected[i])
+			}
+		}
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
+
+func TestWriterErrors(t *testing.T) {
+	if _, _, err := Sum(errReader{}, md5.New); err == nil {
+		t.Error("Sum: expected read error")
+	}
+	w := New(md5.New)
+	w.Sums()
+	if _, err := w.Write([]byte("a")); err != ErrSummed {
+		t.Errorf("Write after Sums: got %v want %v", err, ErrSummed)
+	}
+	if _, err := w.ReadFrom(bytes.NewReader([]byte("a"))); err != ErrSummed {
+		t.Errorf("ReadFrom after Sums: got %v want %v", err, ErrSummed)
+	}
+	w = New(md5.New)
+	if _, err := w.Write([]byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+	if _, err := w.Write([]byte("a")); err != ErrClosed {
+		t.Errorf("Write after Close: got %v want %v", err, ErrClosed)
+	}
+	if sums := w.Sums(); !bytes.Equal(sums[0], sequentialSums([]byte("a"), md5.New)[0]) {
+		t.Error("Sums after Close: wrong digest")
+	}
+	// No algorithms at all.
+	if _, err := io.Copy(New(), bytes.NewReader(randomData(t, 3*bufferSize))); err != nil {
+		t.Error(err)
+	}
+}
+
+// s3ETag computes the multipart ETag of data without the parts suffix.
+func s3ETag(data []byte, partSize int) []byte {
+	var sums []byte
+	for {
+		n := partSize
+		if n > len(data) {
+			n = len(data)
+		}
+		sum := md5.Sum(data[:n])
+		sums = append(sums, sum[:]...)
+		data = data[n:]
+		if len(data) == 0 {
+			break
+		}
+	}
+	etag := md5.Sum(sums)
+	return etag[:]
+}
+
+func TestTree(t *testing.T) {
+	const partSize = 1000
+	for _, size := range []int{0, 1, partSize - 1, partSize, partSize + 1, 10 * partSize, 10*partSize + 7} {
+		data := randomData(t, size)
+		expected := s3ETag(data, partSize)
+		for _, parallel := range []int{1, 4} {
+			tree := NewTree(md5.New, partSize, parallel)
+			for p := data; len(p) > 0; {
+				n := 333
+				if n > len(p) {
+					n = len(p)
+				}
+				tree.Write(p[:n])
+				p = p[n:]
+			}
+			if sum := tree.Sum(nil); !bytes.Equal(sum, expected) {
+				t.Errorf("size %d parallel %d: got %x want %x", size, parallel, sum, expected)
+			}
+			// Sum must not change the state.
+			if sum := tree.Sum(nil); !bytes.Equal(sum, expected) {
+				t.Errorf("size %d parallel %d: second Sum got %x want %x", size, parallel, sum, expected)
+			}
+			wantParts := (size + partSize - 1) / partSize
+			if wantParts == 0 {
+				wantParts = 1
+			}
+			if tree.Parts() != wantParts {
+				t.Errorf("size %d: got %d parts want %d", size, tree.Parts(), wantParts)
+			}
+			tree.Reset()
+			if sum := tree.Sum(nil); !bytes.Equal(sum, s3ETag(nil, partSize)) {
+				t.Errorf("size %d: Sum after Reset got %x", size, sum)
+			}
+		}
+	}
+}
+
+// Tests that a Tree plugs into New as a regular hash.Hash.
+func TestTreeInWriter(t *testing.T) {
+	const partSize = 5 * 1024
+	data := randomData(t, 3*bufferSize+11)
+	tree := func() hash.Hash { return NewTree(md5.New, partSize, 2) }
+	sums, _, err := Sum(bytes.NewReader(data), md5.New, tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := md5.Sum(data); !bytes.Equal(sums[0], expected[:]) {
+		t.Errorf("md5: got %x want %x", sums[0], expected)
+	}
+	if expected := s3ETag(data, partSize); !bytes.Equal(sums[1], expected) {
+		t.Errorf("tree: got %x want %x", sums[1], expected)
+	}
+}
+
+func ExampleTree() {
+	tree := NewTree(md5.New, 5, 2)
+	io.WriteString(tree, "hello world")
+	fmt.Printf("%s-%d\n", hex.EncodeToString(tree.Sum(nil)), tree.Parts())
+	// Output: df349a9519959b17a605009540f4b31d-3
+}
+
+func benchmarkWriter(b *testing.B, algs ...func() hash.Hash) {
+	data := randomData(b, 8*bufferSize)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := New(algs...)
+		w.Write(data)
+		w.Sums()
+	}
+}
+
+func BenchmarkMD5SHA256(b *testing.B) {
+	benchmarkWriter(b, md5.New, sha256.New)
+}
+
+func BenchmarkMD5SHA1SHA256(b *testing.B) {
+	benchmarkWriter(b, md5.New, sha1.New, sha256.New)
+}
@@ -0,0 +1,164 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package multihash
+
+import (
+	"hash"
+	"sync"
+)
+
+// part is the digest of one part, filled in by a worker.
+type part struct {
+	sum []byte
+}
+
+// Tree computes a hash of hashes: the stream is split into parts of a
+// fixed size, every part is hashed on its own and the final digest is
+// the hash of the concatenated part digests. Parts are hashed in
+// parallel. With md5.New this is the S3 multipart ETag without the
+// "-<parts>" suffix.
+//
+// Tree implements hash.Hash, it is not safe for concurrent use.
+type Tree struct {
+	alg      func() hash.Hash
+	partSize int
+
+	buf   []byte        // current part, not dispatched yet
+	free  chan []byte   // recycled part buffers
+	slots chan struct{} // limits parts hashed in parallel
+	parts []*part
+	wg    sync.WaitGroup
+}
+
+// NewTree returns a Tree hashing parts of partSize bytes with alg,
+// using up to parallel goroutines.
+func NewTree(alg func() hash.Hash, partSize int, parallel int) *Tree {
+	if partSize <= 0 {
+		panic("multihash: invalid part size")
+	}
+	if parallel <= 0 {
+		parallel = 1
+	}
+	return &Tree{
+		alg:      alg,
+		partSize: partSize,
+		free:     make(chan []byte, parallel),
+		slots:    make(chan struct{}, parallel),
+	}
+}
+
+// getBuffer returns an empty part buffer, recycling previous ones.
+func (t *Tree) getBuffer() []byte {
+	select {
+	case b := <-t.free:
+		return b[:0]
+	default:
+		return make([]byte, 0, t.partSize)
+	}
+}
+
+// dispatch hashes the current part in the background.
+func (t *Tree) dispatch() {
+	p := &part{}
+	t.parts = append(t.parts, p)
+	buf := t.buf
+	t.buf = nil
+
+	t.slots <- struct{}{}
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		h := t.alg()
+		h.Write(buf)
+		p.sum = h.Sum(nil)
+		select {
+		case t.free <- buf:
+		default:
+		}
+		<-t.slots
+	}()
+}
+
+// Write adds p to the stream, dispatching every completed part.
+func (t *Tree) Write(p []byte) (n int, err error) {
+	n = len(p)
+	for len(p) > 0 {
+		if t.buf == nil {
+			t.buf = t.getBuffer()
+		}
+		m := t.partSize - len(t.buf)
+		if m > len(p) {
+			m = len(p)
+		}
+		t.buf = append(t.buf, p[:m]...)
+		p = p[m:]
+		if len(t.buf) == t.partSize {
+			t.dispatch()
+		}
+	}
+	return n, nil
+}
+
+// PartSums waits for all dispatched parts and returns their digests,
+// including the digest of the trailing incomplete part if any. An empty
+// stream is a single empty part.
+func (t *Tree) PartSums() [][]byte {
+	t.wg.Wait()
+	sums := make([][]byte, 0, len(t.parts)+1)
+	for _, p := range t.parts {
+		sums = append(sums, p.sum)
+	}
+	if len(t.buf) > 0 || len(t.parts) == 0 {
+		h := t.alg()
+		h.Write(t.buf)
+		sums = append(sums, h.Sum(nil))
+	}
+	return sums
+}
+
+// Parts returns the number of parts written so far, including the
+// trailing incomplete part.
+func (t *Tree) Parts() int {
+	if len(t.buf) > 0 || len(t.parts) == 0 {
+		return len(t.parts) + 1
+	}
+	return len(t.parts)
+}
+
+// Sum appends the hash of all part digests to b. It does not change
+// the underlying state, more data can be written afterwards.
+func (t *Tree) Sum(b []byte) []byte {
+	h := t.alg()
+	for _, sum := range t.PartSums() {
+		h.Write(sum)
+	}
+	return h.Sum(b)
+}
+
+// Reset discards all data written so far.
+func (t *Tree) Reset() {
+	t.wg.Wait()
+	t.parts = nil
+	t.buf = t.buf[:0]
+}
+
+// Size returns the digest size of the underlying algorithm.
+func (t *Tree) Size() int { return t.alg().Size() }
+
+// BlockSize returns the part size, writes of this size are hashed
+// without being split.
+func (t *Tree) BlockSize() int { return t.partSize }
@@ -1,4 +1,4 @@
-// +build freebsd darwin windows 386 arm !cgo
+// +build !amd64
 
 /*
  * Minio Cloud Storage, (C) 2014-2016 Minio, Inc.
@@ -1,5 +1,3 @@
-// +build linux,amd64,cgo
-
 /*
  * Minio Cloud Storage, (C) 2016 Minio, Inc.
  *
@@ -29,6 +27,7 @@
 package sha256
 
 import (
+	"errors"
 	"hash"
 
 	"github.com/klauspost/cpuid"
@@ -74,9 +73,68 @@ func (d *digest) Reset() {
 	d.len = 0
 }
 
+const (
+	magic256      = "sha\x03"
+	marshaledSize = len(magic256) + 8*4 + chunk + 8
+)
+
+// MarshalBinary - serializes the digest state, compatible with the
+// format used by the standard library crypto/sha256.
+func (d *digest) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, marshaledSize)
+	b = append(b, magic256...)
+	for _, s := range d.h {
+		b = appendUint32(b, s)
+	}
+	b = append(b, d.x[:d.nx]...)
+	b = b[:len(b)+len(d.x)-d.nx] // already zero
+	b = appendUint64(b, d.len)
+	return b, nil
+}
+
+// UnmarshalBinary - restores the digest state previously serialized
+// by MarshalBinary.
+func (d *digest) UnmarshalBinary(b []byte) error {
+	if len(b) < len(magic256) || string(b[:len(magic256)]) != magic256 {
+		return errors.New("crypto/sha256: invalid hash state identifier")
+	}
+	if len(b) != marshaledSize {
+		return errors.New("crypto/sha256: invalid hash state size")
+	}
+	b = b[len(magic256):]
+	for i := range d.h {
+		b, d.h[i] = consumeUint32(b)
+	}
+	b = b[copy(d.x[:], b):]
+	b, d.len = consumeUint64(b)
+	d.nx = int(d.len % chunk)
+	return nil
+}
+
+func appendUint64(b []byte, x uint64) []byte {
+	return append(b,
+		byte(x>>56), byte(x>>48), byte(x>>40), byte(x>>32),
+		byte(x>>24), byte(x>>16), byte(x>>8), byte(x))
+}
+
+func appendUint32(b []byte, x uint32) []byte {
+	return append(b, byte(x>>24), byte(x>>16), byte(x>>8), byte(x))
+}
+
+func consumeUint64(b []byte) ([]byte, uint64) {
+	x := uint64(b[7]) | uint64(b[6])<<8 | uint64(b[5])<<16 | uint64(b[4])<<24 |
+		uint64(b[3])<<32 | uint64(b[2])<<40 | uint64(b[1])<<48 | uint64(b[0])<<56
+	return b[8:], x
+}
+
+func consumeUint32(b []byte) ([]byte, uint32) {
+	x := uint32(b[3]) | uint32(b[2])<<8 | uint32(b[1])<<16 | uint32(b[0])<<24
+	return b[4:], x
+}
+
 func block(dig *digest, p []byte) {
 	switch true {
-	case cpuid.CPU.AVX2():
+	case cpuid.CPU.AVX2() && cpuid.CPU.BMI2():
 		blockAVX2(dig, p)
 	case cpuid.CPU.AVX():
 		blockAVX(dig, p)
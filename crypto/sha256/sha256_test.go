@@ -28,6 +28,9 @@
 package sha256
 
 import (
+	"bytes"
+	stdsha256 "crypto/sha256"
+	"encoding"
 	"fmt"
 	"io"
 	"testing"
@@ -111,6 +114,82 @@ func TestBlockSize(t *testing.T) {
 	}
 }
 
+// Tests that the hash state can be saved and restored mid-stream.
+func TestGoldenMarshal(t *testing.T) {
+	for _, g := range golden {
+		h := New()
+		h2 := New()
+
+		io.WriteString(h, g.in[:len(g.in)/2])
+
+		state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Errorf("could not marshal: %v", err)
+			continue
+		}
+
+		if err = h2.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+			t.Errorf("could not unmarshal: %v", err)
+			continue
+		}
+
+		io.WriteString(h, g.in[len(g.in)/2:])
+		io.WriteString(h2, g.in[len(g.in)/2:])
+
+		if actual, actual2 := h.Sum(nil), h2.Sum(nil); !bytes.Equal(actual, actual2) {
+			t.Errorf("sha256(%q) = %x != marshaled %x", g.in, actual, actual2)
+		}
+	}
+}
+
+// Tests that the marshaled state is interchangeable with the standard library.
+func TestMarshalCompatibility(t *testing.T) {
+	for _, g := range golden {
+		h := New()
+		s := stdsha256.New()
+
+		io.WriteString(h, g.in[:len(g.in)/2])
+		io.WriteString(s, g.in[:len(g.in)/2])
+
+		state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatalf("could not marshal: %v", err)
+		}
+		stdState, err := s.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatalf("could not marshal: %v", err)
+		}
+		if !bytes.Equal(state, stdState) {
+			t.Fatalf("sha256(%q) state = %x want %x", g.in, state, stdState)
+		}
+
+		h2 := New()
+		if err = h2.(encoding.BinaryUnmarshaler).UnmarshalBinary(stdState); err != nil {
+			t.Fatalf("could not unmarshal: %v", err)
+		}
+		io.WriteString(h2, g.in[len(g.in)/2:])
+		if s := fmt.Sprintf("%x", h2.Sum(nil)); s != g.out {
+			t.Fatalf("sha256(%q) = %s want %s", g.in, s, g.out)
+		}
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	h := New()
+	state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		t.Fatalf("could not marshal: %v", err)
+	}
+	u := h.(encoding.BinaryUnmarshaler)
+	if err = u.UnmarshalBinary(state[:len(state)-1]); err == nil {
+		t.Error("UnmarshalBinary accepted truncated state")
+	}
+	state[0] = 'x'
+	if err = u.UnmarshalBinary(state); err == nil {
+		t.Error("UnmarshalBinary accepted invalid identifier")
+	}
+}
+
 var bench = New()
 var buf = make([]byte, 1024*1024)
 
@@ -1,4 +1,4 @@
-// +build linux,amd64,cgo
+// +build amd64
 
 //
 // Minio Cloud Storage, (C) 2015 Minio, Inc.
@@ -24,26 +24,6 @@
 
 package sha256
 
-// #cgo CFLAGS: -DHAS_SSE41 -DHAS_AVX -DHAS_AVX2
-// #include <stdint.h>
-// void sha256_transform_ssse3 (const char *input_data, uint32_t *digest, unsigned long num_blks);
-// void sha256_transform_avx (const char *input_data, uint32_t *digest, unsigned long num_blks);
-// void sha256_transform_rorx (const char *input_data, uint32_t *digest, unsigned long num_blks);
-import "C"
-import "unsafe"
-
-func blockSSE(dig *digest, p []byte) {
-	C.sha256_transform_ssse3((*C.char)(unsafe.Pointer(&p[0])), (*C.uint32_t)(unsafe.Pointer(&dig.h[0])), (C.ulong)(len(p)/64))
-}
-
-func blockAVX(dig *digest, p []byte) {
-	C.sha256_transform_avx((*C.char)(unsafe.Pointer(&p[0])), (*C.uint32_t)(unsafe.Pointer(&dig.h[0])), (C.ulong)(len(p)/64))
-}
-
-func blockAVX2(dig *digest, p []byte) {
-	C.sha256_transform_rorx((*C.char)(unsafe.Pointer(&p[0])), (*C.uint32_t)(unsafe.Pointer(&dig.h[0])), (C.ulong)(len(p)/64))
-}
-
 func blockGeneric(dig *digest, p []byte) {
 	var w [64]uint32
 	h0, h1, h2, h3, h4, h5, h6, h7 := dig.h[0], dig.h[1], dig.h[2], dig.h[3], dig.h[4], dig.h[5], dig.h[6], dig.h[7]
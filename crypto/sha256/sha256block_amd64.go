@@ -0,0 +1,41 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sha256
+
+// Block transforms implemented in Plan 9 assembly, see
+// sha256block{Ssse,Avx,Avx2}_amd64.s.
+
+//go:noescape
+func blockSsse(h []uint32, message []uint8)
+
+//go:noescape
+func blockAvx(h []uint32, message []uint8)
+
+//go:noescape
+func blockAvx2(h []uint32, message []uint8)
+
+func blockSSE(dig *digest, p []byte) {
+	blockSsse(dig.h[:], p)
+}
+
+func blockAVX(dig *digest, p []byte) {
+	blockAvx(dig.h[:], p)
+}
+
+func blockAVX2(dig *digest, p []byte) {
+	blockAvx2(dig.h[:], p)
+}
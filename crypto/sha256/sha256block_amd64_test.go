@@ -0,0 +1,54 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sha256
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/klauspost/cpuid"
+)
+
+// Tests that blockGeneric (pure Go) and the assembly versions match.
+func TestBlockGeneric(t *testing.T) {
+	blocks := []struct {
+		name      string
+		supported bool
+		block     func(*digest, []byte)
+	}{
+		{"SSSE3", cpuid.CPU.SSSE3(), blockSSE},
+		{"AVX", cpuid.CPU.AVX(), blockAVX},
+		{"AVX2", cpuid.CPU.AVX2() && cpuid.CPU.BMI2(), blockAVX2},
+	}
+	for _, size := range []int{1, 2, 3, 20} {
+		buf := make([]byte, BlockSize*size)
+		rand.Read(buf)
+		gen := New().(*digest)
+		blockGeneric(gen, buf)
+		for _, b := range blocks {
+			if !b.supported {
+				t.Logf("%s not supported on this CPU, skipping", b.name)
+				continue
+			}
+			asm := New().(*digest)
+			b.block(asm, buf)
+			if *gen != *asm {
+				t.Errorf("%s and blockGeneric resulted in different states for %d blocks", b.name, size)
+			}
+		}
+	}
+}
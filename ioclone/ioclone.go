@@ -1,5 +1,5 @@
 /*
- * ioclone (C) 2015 Minio, Inc.
+ * ioclone (C) 2015-2016 Minio, Inc.
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -14,134 +14,260 @@
  * limitations under the License.
  */
 
-//  demux clones io.ReadSeeker.
+// Package ioclone clones one io.Reader into several readers, the source
+// is read only once.
+//
+// Data is read from the source in chunks shared by all clones, every
+// clone buffers a bounded number of chunks.  A clone whose buffers are
+// full and which did not read for its timeout is dropped so that it
+// cannot stall the others, a consumer which fails drops its clone with
+// Close.
 package ioclone
 
 import (
+	"errors"
 	"io"
+	"reflect"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
-// Internal io.ReadSeeker compatible readSeek implementation.
-type readSeek struct {
-	ignore bool            // Failed ones will be ignored.
-	readCh chan<- struct{} // Notify how much we want to read.
-	doneCh <-chan struct{} // Wait for data to be available.
-	buf    []byte          // Buffer to read.
-	n      int             // Bytes actually read.
-	e      error.Error     // Read error if any.
-	mu     *sync.Mutex
-	offset int64
-	whence int
-}
-
-// Seek to relative offset.
-func (r *readSeeker) Seek(offset int64, whence int) (int64, error) {
-	r.Lock()
-	defer r.Unlock()
-
-	// Save the offset.
-	r.offset = offset
-	r.whence = whence
+const (
+	defaultChunkSize = 32 * 1024
+	defaultBuffers   = 8
+	defaultTimeout   = 30 * time.Second
+)
 
-	/* Use the source io.ReadSeeker to perform a fake seek and
-	return params appropriately. Use Read style channeling with
-	the Cloner. */
+var (
+	// ErrTimeout - clone was dropped as it did not read for too long.
+	ErrTimeout = errors.New("ioclone: clone dropped, read timeout")
+	// ErrClosed - read on a closed clone.
+	ErrClosed = errors.New("ioclone: read on closed clone")
+)
 
-	return offset, nil
+// Options - optional behaviour of CloneWithOptions, zero values pick
+// the defaults.
+type Options struct {
+	// ChunkSize is the size of reads from the source, 32KiB by default.
+	ChunkSize int
+	// Buffers is the number of chunks buffered for every clone, 8 by
+	// default.  Memory use is bounded by ChunkSize * Buffers, chunks
+	// are shared and not copied per clone.
+	Buffers int
+	// Timeout is how long a clone whose buffers are full may go
+	// without reading before it is dropped, measured from its last
+	// read or from when its buffers filled up, whichever is later.
+	// 30 seconds by default, negative waits forever.
+	Timeout time.Duration
+	// Timeouts overrides Timeout per clone, Timeouts[i] is the timeout
+	// of clone i.  Missing or zero entries take Timeout.
+	Timeouts []time.Duration
 }
 
-// Read method coordinates with the cloner to stagger the reads from
-// source io.ReadSeeker in a way that no duplicate reads are performed.
-func (r *readSeeker) Read(p []byte) (int, error.Error) {
-	r.Lock()
-	defer r.Unlock()
+// clone is one of the readers returned by Clone.
+type clone struct {
+	lastRead int64         // time of the last Read in UnixNano, atomic
+	timeout  time.Duration // negative for none
+	chunks   chan []byte   // filled by the pump, closed after err is set
+	err      error         // io.EOF, source error or ErrTimeout
+	buf      []byte        // unread part of the current chunk
+	done     chan struct{} // closed by Close
+	once     sync.Once
+}
 
-	// Let the cloner know how much we intend to read.
-	r.buf = p
+// Read reads from the chunks handed over by the pump.
+func (c *clone) Read(p []byte) (int, error) {
+	atomic.StoreInt64(&c.lastRead, time.Now().UnixNano())
+	select {
+	case <-c.done:
+		return 0, ErrClosed
+	default:
+	}
+	if len(c.buf) == 0 {
+		select {
+		case chunk, ok := <-c.chunks:
+			if !ok {
+				return 0, c.err
+			}
+			c.buf = chunk
+		case <-c.done:
+			return 0, ErrClosed
+		}
+	}
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	return n, nil
+}
 
-	// Noify the cloner.
-	r.readCh <- struct{}{}
+// deadline returns when the clone is dropped if its buffers stay full
+// since full, ok is false if it has no timeout.
+func (c *clone) deadline(full time.Time) (t time.Time, ok bool) {
+	if c.timeout < 0 {
+		return t, false
+	}
+	if last := time.Unix(0, atomic.LoadInt64(&c.lastRead)); last.After(full) {
+		full = last
+	}
+	return full.Add(c.timeout), true
+}
 
-	// Wait for data to become ready.
-	<-r.readyCh
-	p = r.buf
+// Close drops the clone, the others are not affected.
+func (c *clone) Close() error {
+	c.once.Do(func() { close(c.done) })
+	return nil
+}
 
-	// Return number of bytes and error if any.
-	return r.n, r.e
+// Clone returns n readers which all read the content of r, r is read
+// only once.  See CloneWithOptions for buffering and timeouts.
+func Clone(r io.Reader, n int) []io.ReadCloser {
+	return CloneWithOptions(r, n, Options{})
 }
 
-// Find smallest number.
-func smallestInt(nums []int) int {
-	if len(nums) < 1 {
-		return -1
+// CloneWithOptions is like Clone with optional behaviour.
+func CloneWithOptions(r io.Reader, n int, opts Options) []io.ReadCloser {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = defaultChunkSize
+	}
+	if opts.Buffers <= 0 {
+		opts.Buffers = defaultBuffers
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultTimeout
+	}
+
+	clones := make([]*clone, n)
+	readers := make([]io.ReadCloser, n)
+	now := time.Now().UnixNano()
+	for i := range clones {
+		timeout := opts.Timeout
+		if i < len(opts.Timeouts) && opts.Timeouts[i] != 0 {
+			timeout = opts.Timeouts[i]
+		}
+		clones[i] = &clone{
+			lastRead: now,
+			timeout:  timeout,
+			chunks:   make(chan []byte, opts.Buffers),
+			done:     make(chan struct{}),
+		}
+		readers[i] = clones[i]
 	}
+	go pump(r, clones, opts)
+	return readers
+}
 
-	var smallest int = nums[0]
-	for i := 0; i < len(nums); i++ {
-		if nums[i] < smallest {
-			smallest = nums[i]
+// pump reads r chunk by chunk and hands every chunk to all live clones,
+// until r fails or no clone is left.
+func pump(r io.Reader, clones []*clone, opts Options) {
+	live := clones
+	for len(live) > 0 {
+		chunk := make([]byte, opts.ChunkSize)
+		n, err := io.ReadFull(r, chunk)
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		if n > 0 {
+			live = send(live, chunk[:n])
+		}
+		if err != nil {
+			for _, c := range live {
+				c.err = err
+				close(c.chunks)
+			}
+			return
 		}
 	}
-	return smallest
 }
 
-// Clone makes cloned copies of ReadSeeker. Reads from individual
-// readSeekers are staggered to avoid duplicate reads from the source
-// reader automatically.
-func Clone(rs io.ReadSeeker, copies int) []io.ReadSeeker {
-	var readers = [copies]readSeek{}
-
-	// Initialize each of the clones.
-	for i = 0; i < count; i++ {
-		readers[i] = readSeek{
-			ignore:  false,
-			mu:      &sync.Mutex{},
-			readCh:  make(chan struct{}),
-			readyCh: make(chan struct{}),
+// send hands chunk to all clones and returns the ones still live.  The
+// clones with full buffers are waited for together, each until its own
+// deadline.
+func send(clones []*clone, chunk []byte) []*clone {
+	live := clones[:0]
+	var full []*clone
+	since := time.Now()
+	for _, c := range clones {
+		switch trySend(c, chunk) {
+		case sent:
+			live = append(live, c)
+		case blocked:
+			full = append(full, c)
 		}
 	}
 
-	// IO demux copier routine.
-	go func() {
-		// 32 secs timeout.
-		timeout := make(chan bool, 32)
-
-		for {
-			var bytesReq []int
-			for i = 0; i < count; i++ {
-				if readers[i].ignore == false {
-					// Previously failed. Continue to ignore.
-					continue
-				}
-
-				// Wait for next read.
-				select {
-				case <-readChs[i]: //bytes requested.
-					bytesReq[i] = len(readers[i].buf)
-				case timeout: // Timeout.
-					readers[i].ignore = true
-				}
+	for len(full) > 0 {
+		// One send and one done case per clone, then the timer of
+		// the earliest deadline if any.
+		var cases []reflect.SelectCase
+		var earliest time.Time
+		for _, c := range full {
+			cases = append(cases,
+				reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(c.chunks), Send: reflect.ValueOf(chunk)},
+				reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.done)})
+			if t, ok := c.deadline(since); ok && (earliest.IsZero() || t.Before(earliest)) {
+				earliest = t
 			}
+		}
+		var timer *time.Timer
+		if !earliest.IsZero() {
+			timer = time.NewTimer(earliest.Sub(time.Now()))
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)})
+		}
+		chosen, _, _ := reflect.Select(cases)
+		if timer != nil {
+			timer.Stop()
+		}
 
-			// Find the next smallest data block to read.
-			bytes2Read := smallestInt(bytesReq)
-			buf := [bytes2Read]byte{}
-			n, e := rs.Read(buf)
-
-			// Copy the data to clones and notify them.
-			for i = 0; i < count; i++ {
-				// Copy the data to clones.
-				readers[i].buf = buf
-				readers[i].n = n
-				readers[i].e = e
+		if chosen < 2*len(full) {
+			c := full[chosen/2]
+			if chosen%2 == 0 {
+				live = append(live, c)
+			}
+			full = append(full[:chosen/2], full[chosen/2+1:]...)
+			continue
+		}
 
-				// Notify ready.
-				readyChs[i] <- struct{}{}
+		// Some deadline passed, clones which can take the chunk now
+		// or read since are kept.
+		now := time.Now()
+		waiting := full[:0]
+		for _, c := range full {
+			switch trySend(c, chunk) {
+			case sent:
+				live = append(live, c)
+				continue
+			case closed:
+				continue
+			}
+			if t, ok := c.deadline(since); ok && !now.Before(t) {
+				c.err = ErrTimeout
+				close(c.chunks)
+				continue
 			}
+			waiting = append(waiting, c)
 		}
-	}()
+		full = waiting
+	}
+	return live
+}
 
-	// Return the clones to the caller.
-	return readers
+// sendResult is the outcome of trySend.
+type sendResult int
+
+const (
+	sent sendResult = iota
+	blocked
+	closed
+)
+
+// trySend hands chunk to c without waiting.
+func trySend(c *clone, chunk []byte) sendResult {
+	select {
+	case c.chunks <- chunk:
+		return sent
+	case <-c.done:
+		return closed
+	default:
+		return blocked
+	}
 }
@@ -0,0 +1,203 @@
+/*
+ * ioclone (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ioclone
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MySuite struct{}
+
+var _ = Suite(&MySuite{})
+
+func randomData(c *C, size int) []byte {
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	c.Assert(err, IsNil)
+	return data
+}
+
+// countingReader counts the bytes read from it.
+type countingReader struct {
+	io.Reader
+	mu sync.Mutex
+	n  int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.mu.Lock()
+	r.n += n
+	r.mu.Unlock()
+	return n, err
+}
+
+func (r *countingReader) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.n
+}
+
+func (s *MySuite) TestClone(c *C) {
+	for _, size := range []int{0, 1, defaultChunkSize, 10*defaultChunkSize + 3} {
+		data := randomData(c, size)
+		src := &countingReader{Reader: bytes.NewReader(data)}
+		readers := Clone(src, 4)
+		c.Assert(readers, HasLen, 4)
+
+		var wg sync.WaitGroup
+		for _, r := range readers {
+			wg.Add(1)
+			go func(r io.ReadCloser) {
+				defer wg.Done()
+				defer r.Close()
+				got, err := ioutil.ReadAll(r)
+				c.Check(err, IsNil)
+				c.Check(bytes.Equal(got, data), Equals, true)
+			}(r)
+		}
+		wg.Wait()
+		// The source is read only once.
+		c.Assert(src.count(), Equals, size)
+	}
+}
+
+func (s *MySuite) TestCloneSlow(c *C) {
+	data := randomData(c, 64*1024)
+	opts := Options{ChunkSize: 1024, Buffers: 2, Timeout: 50 * time.Millisecond}
+	readers := CloneWithOptions(bytes.NewReader(data), 3, opts)
+
+	// The first clone never reads, the others must still complete.
+	var wg sync.WaitGroup
+	for _, r := range readers[1:] {
+		wg.Add(1)
+		go func(r io.ReadCloser) {
+			defer wg.Done()
+			got, err := ioutil.ReadAll(r)
+			c.Check(err, IsNil)
+			c.Check(bytes.Equal(got, data), Equals, true)
+		}(r)
+	}
+	wg.Wait()
+
+	// The slow clone gets its buffered chunks, then the timeout.
+	got, err := ioutil.ReadAll(readers[0])
+	c.Assert(err, Equals, ErrTimeout)
+	c.Assert(len(got), Equals, 2*1024)
+	c.Assert(bytes.Equal(got, data[:len(got)]), Equals, true)
+}
+
+func (s *MySuite) TestCloneTimeouts(c *C) {
+	data := randomData(c, 16*1024)
+	opts := Options{
+		ChunkSize: 1024,
+		Buffers:   1,
+		Timeout:   50 * time.Millisecond,
+		Timeouts:  []time.Duration{0, -1},
+	}
+	readers := CloneWithOptions(bytes.NewReader(data), 3, opts)
+
+	// The first clone never reads and is dropped, the second starts
+	// late but has no timeout, the third reads slower than the source
+	// but never stops for its timeout.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		time.Sleep(200 * time.Millisecond)
+		got, err := ioutil.ReadAll(readers[1])
+		c.Check(err, IsNil)
+		c.Check(bytes.Equal(got, data), Equals, true)
+	}()
+	go func() {
+		defer wg.Done()
+		var got []byte
+		buf := make([]byte, 1024)
+		for {
+			time.Sleep(10 * time.Millisecond)
+			n, err := readers[2].Read(buf)
+			got = append(got, buf[:n]...)
+			if err == io.EOF {
+				break
+			}
+			c.Assert(err, IsNil)
+		}
+		c.Check(bytes.Equal(got, data), Equals, true)
+	}()
+	wg.Wait()
+
+	got, err := ioutil.ReadAll(readers[0])
+	c.Assert(err, Equals, ErrTimeout)
+	c.Assert(bytes.Equal(got, data[:len(got)]), Equals, true)
+}
+
+func (s *MySuite) TestCloneClose(c *C) {
+	data := randomData(c, 64*1024)
+	opts := Options{ChunkSize: 1024, Buffers: 1, Timeout: -1}
+	readers := CloneWithOptions(bytes.NewReader(data), 2, opts)
+
+	// A failed consumer closes its clone, the other one is not blocked
+	// even without a timeout.
+	p := make([]byte, 10)
+	_, err := readers[0].Read(p)
+	c.Assert(err, IsNil)
+	c.Assert(readers[0].Close(), IsNil)
+	_, err = readers[0].Read(p)
+	c.Assert(err, Equals, ErrClosed)
+
+	got, err := ioutil.ReadAll(readers[1])
+	c.Assert(err, IsNil)
+	c.Assert(bytes.Equal(got, data), Equals, true)
+}
+
+func (s *MySuite) TestCloneAllClosed(c *C) {
+	src := &countingReader{Reader: bytes.NewReader(randomData(c, 1<<20))}
+	opts := Options{ChunkSize: 1024, Buffers: 1, Timeout: -1}
+	readers := CloneWithOptions(src, 2, opts)
+	for _, r := range readers {
+		c.Assert(r.Close(), IsNil)
+	}
+	// The pump stops reading the source once nobody is left.
+	time.Sleep(50 * time.Millisecond)
+	c.Assert(src.count() < 1<<20, Equals, true)
+}
+
+type errReader struct{}
+
+var errRead = errors.New("read failed")
+
+func (errReader) Read([]byte) (int, error) { return 0, errRead }
+
+func (s *MySuite) TestCloneError(c *C) {
+	src := io.MultiReader(bytes.NewReader([]byte("hello")), errReader{})
+	for _, r := range Clone(src, 3) {
+		got, err := ioutil.ReadAll(r)
+		c.Assert(err, Equals, errRead)
+		c.Assert(string(got), Equals, "hello")
+	}
+}
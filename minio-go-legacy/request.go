@@ -315,20 +315,7 @@ func (r *request) PreSignV2() (string, error) {
 		r.Set("Date", d.Format(http.TimeFormat))
 	}
 	epochExpires := d.Unix() + r.expires
-	var path string
-	if r.config.isVirtualStyle {
-		for k, v := range regions {
-			if v == r.config.Region {
-				path = "/" + strings.TrimSuffix(r.req.URL.Host, "."+k)
-				path += r.req.URL.Path
-				path = getURLEncodedPath(path)
-				break
-			}
-		}
-	} else {
-		path = getURLEncodedPath(r.req.URL.Path)
-	}
-	signText := fmt.Sprintf("%s\n\n\n%d\n%s", r.req.Method, epochExpires, path)
+	signText := PresignStringToSignV2(r.req, epochExpires, r.getResourcePath())
 	hm := hmac.New(sha1.New, []byte(r.config.SecretAccessKey))
 	hm.Write([]byte(signText))
 
@@ -385,6 +372,27 @@ func (r *request) SignV2() {
 	r.req.Header.Set("Authorization", authHeader.String())
 }
 
+// getResourcePath - returns the request path including the bucket name,
+// for virtual style requests the bucket name is taken from the host.
+func (r *request) getResourcePath() string {
+	if r.config.isVirtualStyle {
+		for k, v := range regions {
+			if v == r.config.Region {
+				return "/" + strings.TrimSuffix(r.req.URL.Host, "."+k) + r.req.URL.Path
+			}
+		}
+	}
+	return r.req.URL.Path
+}
+
+func (r *request) getStringToSign() string {
+	return StringToSignV2(r.req, r.getResourcePath())
+}
+
+// StringToSignV2 - returns the string to sign of a signature V2 request,
+// resourcePath is the unescaped request path including the bucket name.
+// It is shared by the client and the server side verification.
+//
 // From the Amazon docs:
 //
 // StringToSign = HTTP-Verb + "\n" +
@@ -393,32 +401,43 @@ func (r *request) SignV2() {
 //	 Date + "\n" +
 //	 CanonicalizedProtocolHeaders +
 //	 CanonicalizedResource;
-func (r *request) getStringToSign() string {
+func StringToSignV2(req *http.Request, resourcePath string) string {
 	buf := new(bytes.Buffer)
 	// write standard headers
-	r.writeDefaultHeaders(buf)
+	writeDefaultHeaders(buf, req, req.Header.Get("Date"))
 	// write canonicalized protocol headers if any
-	r.writeCanonicalizedHeaders(buf)
+	writeCanonicalizedHeaders(buf, req)
 	// write canonicalized Query resources if any
-	r.writeCanonicalizedResource(buf)
+	writeCanonicalizedResource(buf, req, resourcePath)
+	return buf.String()
+}
+
+// PresignStringToSignV2 - returns the string to sign of a presigned
+// signature V2 request, the Date is replaced by the expiry time in
+// seconds since the epoch.
+func PresignStringToSignV2(req *http.Request, expires int64, resourcePath string) string {
+	buf := new(bytes.Buffer)
+	writeDefaultHeaders(buf, req, strconv.FormatInt(expires, 10))
+	writeCanonicalizedHeaders(buf, req)
+	writeCanonicalizedResource(buf, req, resourcePath)
 	return buf.String()
 }
 
-func (r *request) writeDefaultHeaders(buf *bytes.Buffer) {
-	buf.WriteString(r.req.Method)
+func writeDefaultHeaders(buf *bytes.Buffer, req *http.Request, date string) {
+	buf.WriteString(req.Method)
 	buf.WriteByte('\n')
-	buf.WriteString(r.req.Header.Get("Content-MD5"))
+	buf.WriteString(req.Header.Get("Content-MD5"))
 	buf.WriteByte('\n')
-	buf.WriteString(r.req.Header.Get("Content-Type"))
+	buf.WriteString(req.Header.Get("Content-Type"))
 	buf.WriteByte('\n')
-	buf.WriteString(r.req.Header.Get("Date"))
+	buf.WriteString(date)
 	buf.WriteByte('\n')
 }
 
-func (r *request) writeCanonicalizedHeaders(buf *bytes.Buffer) {
+func writeCanonicalizedHeaders(buf *bytes.Buffer, req *http.Request) {
 	var protoHeaders []string
 	vals := make(map[string][]string)
-	for k, vv := range r.req.Header {
+	for k, vv := range req.Header {
 		// all the AMZ and GOOG headers should be lowercase
 		lk := strings.ToLower(k)
 		if strings.HasPrefix(lk, "x-amz") {
@@ -478,20 +497,9 @@ var resourceList = []string{
 // CanonicalizedResource = [ "/" + Bucket ] +
 // 	  <HTTP-Request-URI, from the protocol name up to the query string> +
 // 	  [ sub-resource, if present. For example "?acl", "?location", "?logging", or "?torrent"];
-func (r *request) writeCanonicalizedResource(buf *bytes.Buffer) error {
-	requestURL := r.req.URL
-	if r.config.isVirtualStyle {
-		for k, v := range regions {
-			if v == r.config.Region {
-				path := "/" + strings.TrimSuffix(requestURL.Host, "."+k)
-				path += requestURL.Path
-				buf.WriteString(getURLEncodedPath(path))
-				break
-			}
-		}
-	} else {
-		buf.WriteString(getURLEncodedPath(requestURL.Path))
-	}
+func writeCanonicalizedResource(buf *bytes.Buffer, req *http.Request, resourcePath string) {
+	requestURL := req.URL
+	buf.WriteString(getURLEncodedPath(resourcePath))
 	sort.Strings(resourceList)
 	if requestURL.RawQuery != "" {
 		var n int
@@ -517,5 +525,4 @@ func (r *request) writeCanonicalizedResource(buf *bytes.Buffer) error {
 			}
 		}
 	}
-	return nil
 }
@@ -45,8 +45,15 @@ var (
 	ratelimitFlag = cli.IntFlag{
 		Name:  "ratelimit",
 		Hide:  true,
-		Value: 16,
-		Usage: "Limit for total concurrent requests: [DEFAULT: 16].",
+		Value: 0,
+		Usage: "Limit for total concurrent requests, shared among reads, writes, listings and multipart uploads, each gets at least one so values below 4 act as 4. A request holds its slot for its whole transfer, requests over the limit are queued and fail with SlowDown when the queue is full or after --ratelimit-timeout: [DEFAULT: 0, unlimited].",
+	}
+
+	ratelimitTimeoutFlag = cli.DurationFlag{
+		Name:  "ratelimit-timeout",
+		Hide:  true,
+		Value: tmQueueTimeout,
+		Usage: "Time a request waits for a slot under --ratelimit before failing with SlowDown, allow for the longest expected transfer: [DEFAULT: 1m].",
 	}
 
 	anonymousFlag = cli.BoolFlag{
@@ -65,6 +72,11 @@ var (
 		Usage: "Provide your domain private key.",
 	}
 
+	qualifyFlag = cli.BoolFlag{
+		Name:  "qualify",
+		Usage: "Reject exports on unsupported filesystems, with less than 1GiB or 10000 inodes free, or below the expected throughput and fsync latency of a benchmark.",
+	}
+
 	jsonFlag = cli.BoolFlag{
 		Name:  "json",
 		Usage: "Enable json formatted output.",
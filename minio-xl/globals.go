@@ -16,7 +16,29 @@
 
 package main
 
+import (
+	"github.com/minio/minio-xl/pkg/scsi"
+	"github.com/minio/minio-xl/pkg/tasker"
+)
+
 var (
 	globalJSONFlag  = false // Json flag set via command line
 	globalDebugFlag = false // Debug flag set via command line
 )
+
+// globalTaskCtl controls the background tasks of the server, they are
+// administered with the "Tasker" RPC service.
+var globalTaskCtl = tasker.New("minio-xl")
+
+// globalTicketMaster admits the API operations of the server, its limit
+// is set by --ratelimit and its state is reported by the "Server" RPC
+// service.
+var globalTicketMaster = newTicketMaster(0)
+
+// globalDiskSampler samples the I/O statistics of the block devices
+// while the server runs, they are reported by the "Server" RPC service.
+var globalDiskSampler = scsi.NewSampler("/")
+
+// globalBucketPolicies holds the bucket policies, they govern anonymous
+// access to buckets and objects.
+var globalBucketPolicies = newBucketPolicyStore()
@@ -49,6 +49,7 @@ func errorIf(err *probe.Error, msg string, fields map[string]interface{}) {
 	if fields == nil {
 		fields = make(map[string]interface{})
 	}
+	err.Resolve() // Fill CallTrace and SysInfo.
 	fields["Error"] = struct {
 		Cause     string             `json:"cause,omitempty"`
 		Type      string             `json:"type,omitempty"`
@@ -61,6 +62,9 @@ func errorIf(err *probe.Error, msg string, fields map[string]interface{}) {
 		err.SysInfo,
 	}
 	log.WithFields(fields).Error(msg)
+	// Registered probe reporters get the same error, failing to report
+	// it is not an error of its own.
+	probe.Report(err, msg)
 }
 
 func fatalIf(err *probe.Error, msg string, fields map[string]interface{}) {
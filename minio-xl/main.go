@@ -22,6 +22,7 @@ import (
 	"os/user"
 	"runtime"
 	"strconv"
+	"time"
 
 	"github.com/dustin/go-humanize"
 	"github.com/minio/cli"
@@ -38,6 +39,7 @@ type minioConfig struct {
 	CertFile          string
 	KeyFile           string
 	RateLimit         int
+	RateLimitTimeout  time.Duration
 }
 
 func init() {
@@ -101,6 +103,7 @@ func registerApp() *cli.App {
 	registerFlag(addressControllerFlag)
 	registerFlag(addressServerRPCFlag)
 	registerFlag(ratelimitFlag)
+	registerFlag(ratelimitTimeoutFlag)
 	registerFlag(anonymousFlag)
 	registerFlag(certFlag)
 	registerFlag(keyFlag)
@@ -22,6 +22,7 @@ import (
 	router "github.com/gorilla/mux"
 	jsonrpc "github.com/gorilla/rpc/v2"
 	"github.com/gorilla/rpc/v2/json"
+	"github.com/minio/minio-xl/pkg/tasker"
 	"github.com/minio/minio-xl/pkg/xl"
 )
 
@@ -46,12 +47,15 @@ func registerAPI(mux *router.Router, a API) {
 
 	// Bucket operations
 	bucket.Methods("GET").HandlerFunc(a.GetBucketACLHandler).Queries("acl", "")
+	bucket.Methods("GET").HandlerFunc(a.GetBucketPolicyHandler).Queries("policy", "")
 	bucket.Methods("GET").HandlerFunc(a.ListMultipartUploadsHandler).Queries("uploads", "")
 	bucket.Methods("GET").HandlerFunc(a.ListObjectsHandler)
 	bucket.Methods("PUT").HandlerFunc(a.PutBucketACLHandler).Queries("acl", "")
+	bucket.Methods("PUT").HandlerFunc(a.PutBucketPolicyHandler).Queries("policy", "")
 	bucket.Methods("PUT").HandlerFunc(a.PutBucketHandler)
 	bucket.Methods("HEAD").HandlerFunc(a.HeadBucketHandler)
 	bucket.Methods("POST").HandlerFunc(a.PostPolicyBucketHandler)
+	bucket.Methods("DELETE").HandlerFunc(a.DeleteBucketPolicyHandler).Queries("policy", "")
 	// Not supported
 	bucket.Methods("DELETE").HandlerFunc(a.DeleteBucketHandler)
 
@@ -59,14 +63,9 @@ func registerAPI(mux *router.Router, a API) {
 	root.Methods("GET").HandlerFunc(a.ListBucketsHandler)
 }
 
-// APIOperation container for individual operations read by Ticket Master
-type APIOperation struct {
-	ProceedCh chan struct{}
-}
-
-// API container for API and also carries OP (operation) channel
+// API container for API and also carries the ticket master admitting operations
 type API struct {
-	OP        chan APIOperation
+	TM        *ticketMaster
 	XL        xl.Interface
 	Anonymous bool // do not checking for incoming signatures, allow all requests
 }
@@ -78,7 +77,7 @@ func getNewAPI(anonymous bool) API {
 	fatalIf(err.Trace(), "Instantiating xl failed.", nil)
 
 	return API{
-		OP:        make(chan APIOperation),
+		TM:        globalTicketMaster,
 		XL:        d,
 		Anonymous: anonymous,
 	}
@@ -111,6 +110,7 @@ func getServerRPCHandler(anonymous bool) http.Handler {
 	s.RegisterCodec(json.NewCodec(), "application/json")
 	s.RegisterService(new(serverRPCService), "Server")
 	s.RegisterService(new(xlRPCService), "XL")
+	s.RegisterService(tasker.NewRPCService(globalTaskCtl), "Tasker")
 	mux := router.NewRouter()
 	mux.Handle("/rpc", s)
 
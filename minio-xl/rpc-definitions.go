@@ -62,9 +62,24 @@ type ServerListRep struct {
 	List []ServerRep `json:"list"`
 }
 
-// DiskStatsRep collection of disks
+// DiskStatsRep collection of disks and their I/O statistics
 type DiskStatsRep struct {
-	Disks []string `json:"disks"`
+	Disks []string   `json:"disks"`
+	Stats []DiskStat `json:"stats"`
+}
+
+// DiskStat I/O statistics of a disk over the last sampling interval
+type DiskStat struct {
+	Name             string  `json:"name"`
+	ReadIOPS         float64 `json:"readIOPS"`
+	WriteIOPS        float64 `json:"writeIOPS"`
+	ReadBytesPerSec  float64 `json:"readBytesPerSec"`
+	WriteBytesPerSec float64 `json:"writeBytesPerSec"`
+	ReadAwaitMs      float64 `json:"readAwaitMs"`
+	WriteAwaitMs     float64 `json:"writeAwaitMs"`
+	AwaitMs          float64 `json:"awaitMs"`
+	Util             float64 `json:"util"` // fraction of the interval the disk was busy
+	InFlight         uint64  `json:"inFlight"`
 }
 
 // MemStatsRep memory statistics of a server
@@ -159,6 +174,24 @@ type ListNodesRep struct {
 	} `json:"nodes"`
 }
 
+// TicketMasterClassStats admission control state of an operation class
+type TicketMasterClassStats struct {
+	Class     string `json:"class"`
+	Limit     int    `json:"limit"`
+	MaxQueue  int    `json:"maxQueue"`
+	Running   int    `json:"running"`
+	Queued    int    `json:"queued"`
+	PeakQueue int    `json:"peakQueue"`
+	Admitted  uint64 `json:"admitted"`
+	Rejected  uint64 `json:"rejected"`
+	TimedOut  uint64 `json:"timedOut"`
+}
+
+// TicketMasterStatsRep reply for Server.TicketMasterStats
+type TicketMasterStatsRep struct {
+	Classes []TicketMasterClassStats `json:"classes"`
+}
+
 // XLVersionRep reply xl on disk format version
 type XLVersionRep struct {
 	Version         string `json:"version"`
@@ -17,9 +17,13 @@
 package main
 
 import (
+	"encoding/hex"
+	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/minio/minio-xl/pkg/crypto/sha256"
 	"github.com/minio/minio-xl/pkg/probe"
 	signv4 "github.com/minio/minio-xl/pkg/signature"
 	"github.com/minio/minio-xl/pkg/xl"
@@ -33,14 +37,12 @@ import (
 // This operation returns at most 1,000 multipart uploads in the response.
 //
 func (api API) ListMultipartUploadsHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opList) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opList)
 
 	resources := getBucketMultipartResources(req.URL.Query())
 	if resources.MaxUploads < 0 {
@@ -81,14 +83,12 @@ func (api API) ListMultipartUploadsHandler(w http.ResponseWriter, req *http.Requ
 // criteria to return a subset of the objects in a bucket.
 //
 func (api API) ListObjectsHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opList) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opList)
 
 	resources := getBucketResources(req.URL.Query())
 	if resources.Maxkeys < 0 {
@@ -133,14 +133,12 @@ func (api API) ListObjectsHandler(w http.ResponseWriter, req *http.Request) {
 // This implementation of the GET operation returns a list of all buckets
 // owned by the authenticated sender of the request.
 func (api API) ListBucketsHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opList) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opList)
 
 	// uncomment this when we have webcli
 	// without access key credentials one cannot list buckets
@@ -168,14 +166,12 @@ func (api API) ListBucketsHandler(w http.ResponseWriter, req *http.Request) {
 // ----------
 // This implementation of the PUT operation creates a new bucket for authenticated request
 func (api API) PutBucketHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opWrite) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opWrite)
 
 	if _, err := stripAccessKeyID(req.Header.Get("Authorization")); err != nil {
 		writeErrorResponse(w, req, AccessDenied, req.URL.Path)
@@ -194,7 +190,7 @@ func (api API) PutBucketHandler(w http.ResponseWriter, req *http.Request) {
 
 	var signature *signv4.Signature
 	if !api.Anonymous {
-		if _, ok := req.Header["Authorization"]; ok {
+		if isRequestSignatureV4(req) {
 			// Init signature V4 verification
 			var err *probe.Error
 			signature, err = initSignatureV4(req)
@@ -243,14 +239,12 @@ func (api API) PutBucketHandler(w http.ResponseWriter, req *http.Request) {
 // This implementation of the POST operation handles object creation with a specified
 // signature policy in multipart/form-data
 func (api API) PostPolicyBucketHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opWrite) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opWrite)
 
 	// if body of request is non-nil then check for validity of Content-Length
 	if req.Body != nil {
@@ -335,14 +329,12 @@ func (api API) PostPolicyBucketHandler(w http.ResponseWriter, req *http.Request)
 // ----------
 // This implementation of the PUT operation modifies the bucketACL for authenticated request
 func (api API) PutBucketACLHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opWrite) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opWrite)
 
 	// read from 'x-amz-acl'
 	aclType := getACLType(req)
@@ -377,14 +369,12 @@ func (api API) PutBucketACLHandler(w http.ResponseWriter, req *http.Request) {
 // know its ``acl``. This operation willl return response of 404
 // if bucket not found and 403 for invalid credentials.
 func (api API) GetBucketACLHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opRead) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opRead)
 
 	vars := mux.Vars(req)
 	bucket := vars["bucket"]
@@ -418,14 +408,12 @@ func (api API) GetBucketACLHandler(w http.ResponseWriter, req *http.Request) {
 // have permission to access it. Otherwise, the operation might
 // return responses such as 404 Not Found and 403 Forbidden.
 func (api API) HeadBucketHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opRead) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opRead)
 
 	vars := mux.Vars(req)
 	bucket := vars["bucket"]
@@ -445,3 +433,153 @@ func (api API) HeadBucketHandler(w http.ResponseWriter, req *http.Request) {
 	}
 	writeSuccessResponse(w)
 }
+
+// bucketExists writes the error response and returns false if bucket
+// does not exist.
+func (api API) bucketExists(w http.ResponseWriter, req *http.Request, bucket string) bool {
+	_, err := api.XL.GetBucketMetadata(bucket)
+	if err != nil {
+		errorIf(err.Trace(), "GetBucketMetadata failed.", nil)
+		switch err.ToGoError().(type) {
+		case xl.BucketNotFound:
+			writeErrorResponse(w, req, NoSuchBucket, req.URL.Path)
+		case xl.BucketNameInvalid:
+			writeErrorResponse(w, req, InvalidBucketName, req.URL.Path)
+		default:
+			writeErrorResponse(w, req, InternalError, req.URL.Path)
+		}
+		return false
+	}
+	return true
+}
+
+// PutBucketPolicyHandler - PUT Bucket policy
+// -----------------
+// This implementation of the PUT operation uses the policy
+// subresource to add to or replace a policy on a bucket
+func (api API) PutBucketPolicyHandler(w http.ResponseWriter, req *http.Request) {
+	// ticket master block
+	if !api.TM.admit(opWrite) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
+	}
+	defer api.TM.release(opWrite)
+
+	vars := mux.Vars(req)
+	bucket := vars["bucket"]
+
+	if !api.bucketExists(w, req, bucket) {
+		return
+	}
+	if req.Body == nil {
+		writeErrorResponse(w, req, MissingRequestBodyError, req.URL.Path)
+		return
+	}
+	// read one byte more than allowed, to tell a too large policy
+	data, e := ioutil.ReadAll(io.LimitReader(req.Body, maxBucketPolicySize+1))
+	if e != nil {
+		errorIf(probe.NewError(e), "Reading bucket policy failed.", nil)
+		writeErrorResponse(w, req, IncompleteBody, req.URL.Path)
+		return
+	}
+	// the payload of signature v4 requests is verified here
+	if !api.Anonymous && isRequestSignatureV4(req) {
+		signature, err := initSignatureV4(req)
+		if err != nil {
+			errorIf(err.Trace(), "Initializing signature v4 failed.", nil)
+			writeErrorResponse(w, req, InternalError, req.URL.Path)
+			return
+		}
+		shaBytes := sha256.Sum256(data)
+		ok, err := signature.DoesSignatureMatch(hex.EncodeToString(shaBytes[:]))
+		if err != nil {
+			errorIf(err.Trace(), "Unable to verify signature.", nil)
+			writeErrorResponse(w, req, InternalError, req.URL.Path)
+			return
+		}
+		if !ok {
+			writeErrorResponse(w, req, SignatureDoesNotMatch, req.URL.Path)
+			return
+		}
+	}
+	if err := globalBucketPolicies.Set(bucket, data); err != nil {
+		errorIf(err.Trace(), "PutBucketPolicy failed.", nil)
+		switch err.ToGoError() {
+		case errMalformedPolicy:
+			writeErrorResponse(w, req, MalformedPolicy, req.URL.Path)
+		default:
+			writeErrorResponse(w, req, InternalError, req.URL.Path)
+		}
+		return
+	}
+	setCommonHeaders(w, 0)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBucketPolicyHandler - GET Bucket policy
+// -----------------
+// This operation uses the policy subresource to return the policy of a
+// specified bucket.
+func (api API) GetBucketPolicyHandler(w http.ResponseWriter, req *http.Request) {
+	// ticket master block
+	if !api.TM.admit(opRead) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
+	}
+	defer api.TM.release(opRead)
+
+	vars := mux.Vars(req)
+	bucket := vars["bucket"]
+
+	if !api.bucketExists(w, req, bucket) {
+		return
+	}
+	_, data, err := globalBucketPolicies.Get(bucket)
+	if err != nil {
+		switch err.ToGoError() {
+		case errNoSuchBucketPolicy:
+			writeErrorResponse(w, req, NoSuchBucketPolicy, req.URL.Path)
+		default:
+			errorIf(err.Trace(), "GetBucketPolicy failed.", nil)
+			writeErrorResponse(w, req, InternalError, req.URL.Path)
+		}
+		return
+	}
+	// write headers
+	setCommonHeaders(w, len(data))
+	w.Header().Set("Content-Type", "application/json")
+	// write body
+	w.Write(data)
+}
+
+// DeleteBucketPolicyHandler - DELETE Bucket policy
+// -----------------
+// This implementation of the DELETE operation uses the policy
+// subresource to remove a policy on a bucket.
+func (api API) DeleteBucketPolicyHandler(w http.ResponseWriter, req *http.Request) {
+	// ticket master block
+	if !api.TM.admit(opWrite) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
+	}
+	defer api.TM.release(opWrite)
+
+	vars := mux.Vars(req)
+	bucket := vars["bucket"]
+
+	if !api.bucketExists(w, req, bucket) {
+		return
+	}
+	if err := globalBucketPolicies.Remove(bucket); err != nil {
+		switch err.ToGoError() {
+		case errNoSuchBucketPolicy:
+			writeErrorResponse(w, req, NoSuchBucketPolicy, req.URL.Path)
+		default:
+			errorIf(err.Trace(), "DeleteBucketPolicy failed.", nil)
+			writeErrorResponse(w, req, InternalError, req.URL.Path)
+		}
+		return
+	}
+	setCommonHeaders(w, 0)
+	w.WriteHeader(http.StatusNoContent)
+}
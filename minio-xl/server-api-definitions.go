@@ -196,7 +196,6 @@ type CompleteMultipartUploadResponse struct {
 
 // List of not implemented bucket queries
 var notimplementedBucketResourceNames = map[string]bool{
-	"policy":         true,
 	"cors":           true,
 	"lifecycle":      true,
 	"location":       true,
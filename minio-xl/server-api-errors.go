@@ -72,6 +72,9 @@ const (
 	InvalidPartOrder
 	AuthorizationHeaderMalformed
 	MalformedPOSTRequest
+	SlowDown
+	MalformedPolicy
+	NoSuchBucketPolicy
 )
 
 // APIError code to Error structure map
@@ -231,6 +234,21 @@ var errorCodeResponse = map[int]APIError{
 		Description:    "The body of your POST request is not well-formed multipart/form-data.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	SlowDown: {
+		Code:           "SlowDown",
+		Description:    "Please reduce your request rate.",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
+	MalformedPolicy: {
+		Code:           "MalformedPolicy",
+		Description:    "The bucket policy is not valid JSON or uses an unsupported feature.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	NoSuchBucketPolicy: {
+		Code:           "NoSuchBucketPolicy",
+		Description:    "The bucket policy does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
 }
 
 // errorCodeError provides errorCode to Error. It returns empty if the code provided is unknown
@@ -36,13 +36,11 @@ const (
 // you must have READ access to the object.
 func (api API) GetObjectHandler(w http.ResponseWriter, req *http.Request) {
 	// ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	if !api.TM.admit(opRead) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opRead)
 
 	var object, bucket string
 	vars := mux.Vars(req)
@@ -84,13 +82,11 @@ func (api API) GetObjectHandler(w http.ResponseWriter, req *http.Request) {
 // The HEAD operation retrieves metadata from an object without returning the object itself.
 func (api API) HeadObjectHandler(w http.ResponseWriter, req *http.Request) {
 	// ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	if !api.TM.admit(opRead) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opRead)
 
 	var object, bucket string
 	vars := mux.Vars(req)
@@ -122,14 +118,12 @@ func (api API) HeadObjectHandler(w http.ResponseWriter, req *http.Request) {
 // ----------
 // This implementation of the PUT operation adds an object to a bucket.
 func (api API) PutObjectHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opWrite) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opWrite)
 
 	var object, bucket string
 	vars := mux.Vars(req)
@@ -175,7 +169,7 @@ func (api API) PutObjectHandler(w http.ResponseWriter, req *http.Request) {
 
 	var signature *signv4.Signature
 	if !api.Anonymous {
-		if _, ok := req.Header["Authorization"]; ok {
+		if isRequestSignatureV4(req) {
 			// Init signature V4 verification
 			var err *probe.Error
 			signature, err = initSignatureV4(req)
@@ -222,14 +216,12 @@ func (api API) PutObjectHandler(w http.ResponseWriter, req *http.Request) {
 
 // NewMultipartUploadHandler - New multipart upload
 func (api API) NewMultipartUploadHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opMultipart) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opMultipart)
 
 	var object, bucket string
 	vars := mux.Vars(req)
@@ -258,14 +250,12 @@ func (api API) NewMultipartUploadHandler(w http.ResponseWriter, req *http.Reques
 
 // PutObjectPartHandler - Upload part
 func (api API) PutObjectPartHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opMultipart) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opMultipart)
 
 	// get Content-MD5 sent by client and verify if valid
 	md5 := req.Header.Get("Content-MD5")
@@ -316,7 +306,7 @@ func (api API) PutObjectPartHandler(w http.ResponseWriter, req *http.Request) {
 
 	var signature *signv4.Signature
 	if !api.Anonymous {
-		if _, ok := req.Header["Authorization"]; ok {
+		if isRequestSignatureV4(req) {
 			// Init signature V4 verification
 			var err *probe.Error
 			signature, err = initSignatureV4(req)
@@ -357,14 +347,12 @@ func (api API) PutObjectPartHandler(w http.ResponseWriter, req *http.Request) {
 
 // AbortMultipartUploadHandler - Abort multipart upload
 func (api API) AbortMultipartUploadHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opMultipart) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opMultipart)
 
 	vars := mux.Vars(req)
 	bucket := vars["bucket"]
@@ -389,14 +377,12 @@ func (api API) AbortMultipartUploadHandler(w http.ResponseWriter, req *http.Requ
 
 // ListObjectPartsHandler - List object parts
 func (api API) ListObjectPartsHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opList) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opList)
 
 	objectResourcesMetadata := getObjectResources(req.URL.Query())
 	if objectResourcesMetadata.PartNumberMarker < 0 {
@@ -436,14 +422,12 @@ func (api API) ListObjectPartsHandler(w http.ResponseWriter, req *http.Request)
 
 // CompleteMultipartUploadHandler - Complete multipart upload
 func (api API) CompleteMultipartUploadHandler(w http.ResponseWriter, req *http.Request) {
-	// Ticket master block
-	{
-		op := APIOperation{}
-		op.ProceedCh = make(chan struct{})
-		api.OP <- op
-		// block until Ticket master gives us a go
-		<-op.ProceedCh
+	// ticket master block
+	if !api.TM.admit(opMultipart) {
+		writeErrorResponse(w, req, SlowDown, req.URL.Path)
+		return
 	}
+	defer api.TM.release(opMultipart)
 
 	vars := mux.Vars(req)
 	bucket := vars["bucket"]
@@ -453,7 +437,7 @@ func (api API) CompleteMultipartUploadHandler(w http.ResponseWriter, req *http.R
 
 	var signature *signv4.Signature
 	if !api.Anonymous {
-		if _, ok := req.Header["Authorization"]; ok {
+		if isRequestSignatureV4(req) {
 			// Init signature V4 verification
 			var err *probe.Error
 			signature, err = initSignatureV4(req)
@@ -0,0 +1,195 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-xl/pkg/probe"
+	"github.com/minio/minio-xl/pkg/safe"
+)
+
+// errNoSuchBucketPolicy means that the bucket has no policy.
+var errNoSuchBucketPolicy = errors.New("The bucket policy does not exist")
+
+// getBucketPolicyPath get bucket policies path, next to the users config
+func getBucketPolicyPath() (string, *probe.Error) {
+	authConfigPath, err := getAuthConfigPath()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(authConfigPath, "policies"), nil
+}
+
+// cachedBucketPolicy - a parsed policy and the document it came from
+type cachedBucketPolicy struct {
+	policy bucketPolicy
+	data   []byte
+}
+
+// bucketPolicyStore keeps the policy of every bucket as <bucket>.json in
+// the bucket policies path.  All policies are read and parsed on first
+// use and then served from memory, as they are evaluated for every
+// anonymous request.
+type bucketPolicyStore struct {
+	mutex    sync.RWMutex
+	loaded   bool
+	policies map[string]cachedBucketPolicy
+
+	// writeMutex serializes Set and Remove, which update the policy
+	// files without holding mutex.
+	writeMutex sync.Mutex
+}
+
+func newBucketPolicyStore() *bucketPolicyStore {
+	return &bucketPolicyStore{}
+}
+
+func getBucketPolicyFile(bucket string) (string, *probe.Error) {
+	policyPath, err := getBucketPolicyPath()
+	if err != nil {
+		return "", err.Trace()
+	}
+	return filepath.Join(policyPath, bucket+".json"), nil
+}
+
+// Get returns the policy of bucket and its document, errNoSuchBucketPolicy
+// if it has none.
+func (s *bucketPolicyStore) Get(bucket string) (bucketPolicy, []byte, *probe.Error) {
+	s.mutex.RLock()
+	loaded := s.loaded
+	cached, ok := s.policies[bucket]
+	s.mutex.RUnlock()
+	if !loaded {
+		if err := s.load(); err != nil {
+			return bucketPolicy{}, nil, err.Trace(bucket)
+		}
+		s.mutex.RLock()
+		cached, ok = s.policies[bucket]
+		s.mutex.RUnlock()
+	}
+	if !ok {
+		return bucketPolicy{}, nil, probe.NewError(errNoSuchBucketPolicy)
+	}
+	return cached.policy, cached.data, nil
+}
+
+// load reads all policies, unless already done.
+func (s *bucketPolicyStore) load() *probe.Error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.loaded {
+		return nil
+	}
+	policyPath, err := getBucketPolicyPath()
+	if err != nil {
+		return err.Trace()
+	}
+	policies := make(map[string]cachedBucketPolicy)
+	files, e := ioutil.ReadDir(policyPath)
+	if e != nil && !os.IsNotExist(e) {
+		return probe.NewError(e)
+	}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		bucket := strings.TrimSuffix(file.Name(), ".json")
+		policyFile := filepath.Join(policyPath, file.Name())
+		// A bad policy file only leaves its bucket without policy, it
+		// can still be replaced or removed.
+		data, e := ioutil.ReadFile(policyFile)
+		if e != nil {
+			errorIf(probe.NewError(e), "Skipping unreadable bucket policy.", nil)
+			continue
+		}
+		policy, err := parseBucketPolicy(bucket, data)
+		if err != nil {
+			errorIf(err.Trace(policyFile), "Skipping invalid bucket policy.", nil)
+			continue
+		}
+		policies[bucket] = cachedBucketPolicy{policy: policy, data: data}
+	}
+	s.policies = policies
+	s.loaded = true
+	return nil
+}
+
+// Set validates and saves the policy document of bucket.
+func (s *bucketPolicyStore) Set(bucket string, data []byte) *probe.Error {
+	policy, err := parseBucketPolicy(bucket, data)
+	if err != nil {
+		return err.Trace(bucket)
+	}
+	if err = s.load(); err != nil {
+		return err.Trace()
+	}
+	policyFile, err := getBucketPolicyFile(bucket)
+	if err != nil {
+		return err.Trace()
+	}
+
+	// Evaluations go on while the file is written, they only wait for
+	// the swap of the cached policy.
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+	file, e := safe.CreateFile(policyFile)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if _, e = file.Write(data); e != nil {
+		file.Abort()
+		return probe.NewError(e)
+	}
+	if e = file.Close(); e != nil {
+		return probe.NewError(e)
+	}
+	s.mutex.Lock()
+	s.policies[bucket] = cachedBucketPolicy{policy: policy, data: data}
+	s.mutex.Unlock()
+	return nil
+}
+
+// Remove deletes the policy of bucket, errNoSuchBucketPolicy if it has
+// none.
+func (s *bucketPolicyStore) Remove(bucket string) *probe.Error {
+	if err := s.load(); err != nil {
+		return err.Trace()
+	}
+	policyFile, err := getBucketPolicyFile(bucket)
+	if err != nil {
+		return err.Trace()
+	}
+
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+	if e := os.Remove(policyFile); e != nil {
+		if os.IsNotExist(e) {
+			return probe.NewError(errNoSuchBucketPolicy)
+		}
+		return probe.NewError(e)
+	}
+	s.mutex.Lock()
+	delete(s.policies, bucket)
+	s.mutex.Unlock()
+	return nil
+}
@@ -0,0 +1,395 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/minio/minio-xl/pkg/probe"
+)
+
+// Bucket policies follow the access policy language of S3, see
+// http://docs.aws.amazon.com/AmazonS3/latest/dev/access-policy-language-overview.html
+//
+// Only anonymous access is governed by policies, so the only supported
+// principal is "*".  The model is the one s3verify tests against.
+
+// maxBucketPolicySize - largest policy accepted, like S3
+const maxBucketPolicySize = 20 * 1024
+
+// Resource prefix for all aws resources.
+const awsResourcePrefix = "arn:aws:s3:::"
+
+// Supported policy language versions.
+var bucketPolicyVersions = map[string]bool{
+	"2012-10-17": true,
+	"2008-10-17": true,
+}
+
+// Supported actions, policies are evaluated only for these.
+var bucketPolicyActions = map[string]bool{
+	"s3:ListBucket":                 true,
+	"s3:ListBucketMultipartUploads": true,
+	"s3:GetObject":                  true,
+	"s3:PutObject":                  true,
+	"s3:DeleteObject":               true,
+	"s3:AbortMultipartUpload":       true,
+	"s3:ListMultipartUploadParts":   true,
+}
+
+// Supported condition keys, set from the request by getPolicyConditions.
+var bucketPolicyConditionKeys = map[string]bool{
+	"s3:prefix":     true,
+	"s3:delimiter":  true,
+	"s3:max-keys":   true,
+	"aws:Referer":   true,
+	"aws:UserAgent": true,
+}
+
+// errMalformedPolicy means that a bucket policy is not valid JSON or
+// uses unsupported features.
+var errMalformedPolicy = errors.New("Malformed bucket policy")
+
+// policyStringSet - a JSON string or array of strings.
+type policyStringSet []string
+
+// UnmarshalJSON accepts both a string and an array of strings.
+func (set *policyStringSet) UnmarshalJSON(data []byte) error {
+	var values []string
+	if err := json.Unmarshal(data, &values); err == nil {
+		*set = values
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*set = policyStringSet{value}
+	return nil
+}
+
+// policyPrincipal - "*" or {"AWS": ...}, both mean everyone for "*".
+type policyPrincipal struct {
+	AWS policyStringSet `json:"AWS,omitempty"`
+}
+
+// UnmarshalJSON accepts the "*" shorthand.
+func (p *policyPrincipal) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		p.AWS = policyStringSet{value}
+		return nil
+	}
+	type principal policyPrincipal // without UnmarshalJSON
+	return json.Unmarshal(data, (*principal)(p))
+}
+
+// policyConditions - condition operator to condition key to values.
+type policyConditions map[string]map[string]policyStringSet
+
+// policyStatement - a statement of a bucket policy
+type policyStatement struct {
+	Sid        string           `json:"Sid,omitempty"`
+	Effect     string           `json:"Effect"`
+	Principal  policyPrincipal  `json:"Principal"`
+	Actions    policyStringSet  `json:"Action"`
+	Resources  policyStringSet  `json:"Resource"`
+	Conditions policyConditions `json:"Condition,omitempty"`
+}
+
+// bucketPolicy - a bucket access policy
+type bucketPolicy struct {
+	Version    string            `json:"Version"`
+	Statements []policyStatement `json:"Statement"`
+}
+
+// malformedPolicy returns errMalformedPolicy, traced with the reason.
+func malformedPolicy(format string, a ...interface{}) *probe.Error {
+	return probe.NewError(errMalformedPolicy).Trace(fmt.Sprintf(format, a...))
+}
+
+// parseBucketPolicy parses and validates the policy of bucket.
+func parseBucketPolicy(bucket string, data []byte) (bucketPolicy, *probe.Error) {
+	var policy bucketPolicy
+	if len(data) > maxBucketPolicySize {
+		return policy, malformedPolicy("policy is larger than %d bytes", maxBucketPolicySize)
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return policy, malformedPolicy("%s", err)
+	}
+	if !bucketPolicyVersions[policy.Version] {
+		return policy, malformedPolicy("unsupported version %q", policy.Version)
+	}
+	if len(policy.Statements) == 0 {
+		return policy, malformedPolicy("no statements")
+	}
+	for i, statement := range policy.Statements {
+		if err := validatePolicyStatement(bucket, statement); err != nil {
+			return policy, malformedPolicy("statement %d: %s", i, err)
+		}
+	}
+	return policy, nil
+}
+
+func validatePolicyStatement(bucket string, statement policyStatement) error {
+	if statement.Effect != "Allow" && statement.Effect != "Deny" {
+		return fmt.Errorf("invalid effect %q", statement.Effect)
+	}
+	if len(statement.Principal.AWS) != 1 || statement.Principal.AWS[0] != "*" {
+		return errors.New("only the \"*\" principal is supported")
+	}
+	if len(statement.Actions) == 0 {
+		return errors.New("no actions")
+	}
+	for _, action := range statement.Actions {
+		if action != "s3:*" && !bucketPolicyActions[action] {
+			return fmt.Errorf("unsupported action %q", action)
+		}
+	}
+	if len(statement.Resources) == 0 {
+		return errors.New("no resources")
+	}
+	bucketResource := awsResourcePrefix + bucket
+	for _, resource := range statement.Resources {
+		if resource != bucketResource && !strings.HasPrefix(resource, bucketResource+"/") {
+			return fmt.Errorf("resource %q is not in bucket %q", resource, bucket)
+		}
+	}
+	for operator, keys := range statement.Conditions {
+		if _, ok := policyConditionOperators[operator]; !ok {
+			return fmt.Errorf("unsupported condition %q", operator)
+		}
+		for key := range keys {
+			if !bucketPolicyConditionKeys[key] {
+				return fmt.Errorf("unsupported condition key %q", key)
+			}
+		}
+	}
+	return nil
+}
+
+// policyConditionOperators - supported condition operators, each tells if
+// a request value matches a policy value and if the condition is negated.
+var policyConditionOperators = map[string]struct {
+	match  func(value, pattern string) bool
+	negate bool
+}{
+	"StringEquals":    {stringEquals, false},
+	"StringNotEquals": {stringEquals, true},
+	"StringLike":      {stringLike, false},
+	"StringNotLike":   {stringLike, true},
+}
+
+func stringEquals(value, pattern string) bool {
+	return value == pattern
+}
+
+// stringLike matches with the "*" and "?" wildcards of the policy
+// language, which unlike path.Match also match "/".
+func stringLike(value, pattern string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			pattern = strings.TrimLeft(pattern, "*")
+			if pattern == "" {
+				return true
+			}
+			for i := 0; i <= len(value); i++ {
+				if stringLike(value[i:], pattern) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if value == "" {
+				return false
+			}
+			value, pattern = value[1:], pattern[1:]
+		default:
+			if value == "" || value[0] != pattern[0] {
+				return false
+			}
+			value, pattern = value[1:], pattern[1:]
+		}
+	}
+	return value == ""
+}
+
+// matchConditions tells if the conditions hold for the request values.  A
+// condition on a key the request does not carry only holds if negated.
+func (s policyStatement) matchConditions(values map[string]string) bool {
+	for operator, keys := range s.Conditions {
+		op := policyConditionOperators[operator]
+		for key, patterns := range keys {
+			value, ok := values[key]
+			matched := false
+			if ok {
+				for _, pattern := range patterns {
+					if op.match(value, pattern) {
+						matched = true
+						break
+					}
+				}
+			}
+			if matched == op.negate {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matches tells if the statement applies to action on resource.
+func (s policyStatement) matches(action, resource string, values map[string]string) bool {
+	actionMatched := false
+	for _, a := range s.Actions {
+		if a == action || a == "s3:*" {
+			actionMatched = true
+			break
+		}
+	}
+	if !actionMatched {
+		return false
+	}
+	resourceMatched := false
+	for _, r := range s.Resources {
+		if stringLike(resource, r) {
+			resourceMatched = true
+			break
+		}
+	}
+	return resourceMatched && s.matchConditions(values)
+}
+
+// isAllowed evaluates the policy for an anonymous request of action on
+// resource, with the condition values of the request.  An explicit Deny
+// overrides any Allow, without a matching Allow the request is denied.
+func (p bucketPolicy) isAllowed(action, resource string, values map[string]string) bool {
+	allowed := false
+	for _, statement := range p.Statements {
+		if !statement.matches(action, resource, values) {
+			continue
+		}
+		if statement.Effect == "Deny" {
+			return false
+		}
+		allowed = true
+	}
+	return allowed
+}
+
+// getPolicyResource returns the resource of a bucket or, if object is not
+// empty, of an object.
+func getPolicyResource(bucket, object string) string {
+	if object == "" {
+		return awsResourcePrefix + bucket
+	}
+	return awsResourcePrefix + bucket + "/" + object
+}
+
+// getPolicyAction returns the bucket and object of a request and the
+// action it performs, empty if policies can not allow the request.
+func getPolicyAction(req *http.Request) (bucket, object, action string) {
+	splits := strings.SplitN(strings.TrimPrefix(req.URL.Path, "/"), "/", 2)
+	bucket = splits[0]
+	if len(splits) == 2 {
+		object = splits[1]
+	}
+	if bucket == "" {
+		return "", "", ""
+	}
+	q := req.URL.Query()
+	// access control is never anonymous
+	if _, ok := q["acl"]; ok {
+		return bucket, object, ""
+	}
+	if _, ok := q["policy"]; ok {
+		return bucket, object, ""
+	}
+	_, uploads := q["uploads"]
+	_, uploadID := q["uploadId"]
+
+	if object == "" {
+		switch req.Method {
+		case "GET", "HEAD":
+			if uploads {
+				return bucket, object, "s3:ListBucketMultipartUploads"
+			}
+			// ?location is not implemented
+			if _, ok := q["location"]; ok {
+				return bucket, object, ""
+			}
+			return bucket, object, "s3:ListBucket"
+		}
+		return bucket, object, ""
+	}
+	switch req.Method {
+	case "GET", "HEAD":
+		if uploadID {
+			return bucket, object, "s3:ListMultipartUploadParts"
+		}
+		return bucket, object, "s3:GetObject"
+	case "PUT", "POST":
+		// multipart uploads need s3:PutObject as well
+		return bucket, object, "s3:PutObject"
+	case "DELETE":
+		if uploadID {
+			return bucket, object, "s3:AbortMultipartUpload"
+		}
+		return bucket, object, "s3:DeleteObject"
+	}
+	return bucket, object, ""
+}
+
+// getPolicyConditions returns the values of the condition keys a request
+// carries.
+func getPolicyConditions(req *http.Request) map[string]string {
+	values := make(map[string]string)
+	q := req.URL.Query()
+	for _, key := range []string{"prefix", "delimiter", "max-keys"} {
+		if v, ok := q[key]; ok {
+			values["s3:"+key] = v[0]
+		}
+	}
+	if referer := req.Header.Get("Referer"); referer != "" {
+		values["aws:Referer"] = referer
+	}
+	if userAgent := req.Header.Get("User-Agent"); userAgent != "" {
+		values["aws:UserAgent"] = userAgent
+	}
+	return values
+}
+
+// isAllowedAnonymous tells if the policy of the bucket of an anonymous
+// request allows it.
+func isAllowedAnonymous(policies *bucketPolicyStore, req *http.Request) bool {
+	bucket, object, action := getPolicyAction(req)
+	if action == "" {
+		return false
+	}
+	// Failures to read the policies are not logged here, for every
+	// request, the bucket policy API reports them.
+	policy, _, err := policies.Get(bucket)
+	if err != nil {
+		return false
+	}
+	return policy.isAllowed(action, getPolicyResource(bucket, object), getPolicyConditions(req))
+}
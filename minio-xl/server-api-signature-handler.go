@@ -24,6 +24,7 @@ import (
 	"github.com/minio/minio-xl/pkg/crypto/sha256"
 	"github.com/minio/minio-xl/pkg/probe"
 	signv4 "github.com/minio/minio-xl/pkg/signature"
+	"github.com/minio/minio-xl/pkg/signv2"
 )
 
 type signatureHandler struct {
@@ -36,10 +37,7 @@ func SignatureHandler(h http.Handler) http.Handler {
 }
 
 func isRequestSignatureV4(req *http.Request) bool {
-	if _, ok := req.Header["Authorization"]; ok {
-		return ok
-	}
-	return false
+	return strings.HasPrefix(req.Header.Get("Authorization"), authHeaderPrefix)
 }
 
 func isRequestPresignedSignatureV4(req *http.Request) bool {
@@ -131,5 +129,56 @@ func (s signatureHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		s.handler.ServeHTTP(w, r)
 		return
 	}
+	if signv2.IsRequestSignatureV2(r) || signv2.IsRequestPresignedSignatureV2(r) {
+		// Signature V2 does not sign the payload, verify here for all requests.
+		signature, err := initSignatureV2(r)
+		if err != nil {
+			switch err.ToGoError() {
+			case errAccessKeyIDInvalid:
+				errorIf(err.Trace(), "Invalid access key id.", nil)
+				writeErrorResponse(w, r, InvalidAccessKeyID, r.URL.Path)
+				return
+			case signv2.ErrInvalidAuthHeader:
+				errorIf(err.Trace(), "Malformed authorization header.", nil)
+				writeErrorResponse(w, r, AuthorizationHeaderMalformed, r.URL.Path)
+				return
+			default:
+				errorIf(err.Trace(), "Initializing signature v2 failed.", nil)
+				writeErrorResponse(w, r, InternalError, r.URL.Path)
+				return
+			}
+		}
+		var ok bool
+		if signv2.IsRequestPresignedSignatureV2(r) {
+			ok, err = signature.DoesPresignedSignatureMatch()
+		} else {
+			ok, err = signature.DoesSignatureMatch()
+		}
+		if err != nil {
+			switch err.ToGoError() {
+			case signv2.ErrMissingDateHeader, signv2.ErrMalformedDate, signv2.ErrRequestTimeTooSkewed:
+				writeErrorResponse(w, r, RequestTimeTooSkewed, r.URL.Path)
+				return
+			case signv2.ErrExpiredPresignRequest, signv2.ErrMalformedExpires:
+				writeErrorResponse(w, r, AccessDenied, r.URL.Path)
+				return
+			default:
+				errorIf(err.Trace(), "Unable to verify signature.", nil)
+				writeErrorResponse(w, r, InternalError, r.URL.Path)
+				return
+			}
+		}
+		if !ok {
+			writeErrorResponse(w, r, SignatureDoesNotMatch, r.URL.Path)
+			return
+		}
+		s.handler.ServeHTTP(w, r)
+		return
+	}
+	// Anonymous requests are governed by the bucket policy.
+	if r.Header.Get("Authorization") == "" && isAllowedAnonymous(globalBucketPolicies, r) {
+		s.handler.ServeHTTP(w, r)
+		return
+	}
 	writeErrorResponse(w, r, AccessDenied, r.URL.Path)
 }
@@ -28,6 +28,7 @@ import (
 
 	"github.com/minio/minio-xl/pkg/probe"
 	signv4 "github.com/minio/minio-xl/pkg/signature"
+	"github.com/minio/minio-xl/pkg/signv2"
 )
 
 const (
@@ -262,3 +263,29 @@ func initPresignedSignatureV4(req *http.Request) (*signv4.Signature, *probe.Erro
 	}
 	return nil, probe.NewError(errAccessKeyIDInvalid)
 }
+
+// initSignatureV2 initializing signature V2 verification
+func initSignatureV2(req *http.Request) (*signv2.Signature, *probe.Error) {
+	accessKeyID, err := signv2.GetAccessKeyID(req)
+	if err != nil {
+		return nil, err.Trace()
+	}
+	if !IsValidAccessKey(accessKeyID) {
+		return nil, probe.NewError(errAccessKeyIDInvalid)
+	}
+	authConfig, err := LoadConfig()
+	if err != nil {
+		return nil, err.Trace()
+	}
+	for _, user := range authConfig.Users {
+		if user.AccessKeyID == accessKeyID {
+			signature := &signv2.Signature{
+				AccessKeyID:     user.AccessKeyID,
+				SecretAccessKey: user.SecretAccessKey,
+				Request:         req,
+			}
+			return signature, nil
+		}
+	}
+	return nil, probe.NewError(errAccessKeyIDInvalid)
+}
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/minio/cli"
 	"github.com/minio/minio-xl/pkg/minhttp"
@@ -119,17 +120,15 @@ func configureServerRPC(conf minioConfig, rpcHandler http.Handler) (*http.Server
 	return rpcServer, nil
 }
 
-// Start ticket master
-func startTM(api API) {
-	for {
-		for op := range api.OP {
-			op.ProceedCh <- struct{}{}
-		}
-	}
-}
+// diskStatsInterval is the sampling interval of the disk statistics.
+const diskStatsInterval = 10 * time.Second
 
 // startServer starts an s3 compatible cloud storage server
 func startServer(conf minioConfig) *probe.Error {
+	globalTicketMaster.setRateLimit(conf.RateLimit)
+	globalTicketMaster.setQueueTimeout(conf.RateLimitTimeout)
+	globalDiskSampler.Start(diskStatsInterval)
+	defer globalDiskSampler.Stop()
 	minioAPI := getNewAPI(conf.Anonymous)
 	apiHandler := getAPIHandler(conf.Anonymous, minioAPI)
 	apiServer, err := configureAPIServer(conf, apiHandler)
@@ -137,9 +136,6 @@ func startServer(conf minioConfig) *probe.Error {
 		return err.Trace()
 	}
 	rpcServer, err := configureServerRPC(conf, getServerRPCHandler(conf.Anonymous))
-
-	// start ticket master
-	go startTM(minioAPI)
 	if err := minhttp.ListenAndServe(apiServer, rpcServer); err != nil {
 		return err.Trace()
 	}
@@ -154,13 +150,14 @@ func getServerConfig(c *cli.Context) minioConfig {
 	}
 	tls := (certFile != "" && keyFile != "")
 	return minioConfig{
-		Address:    c.GlobalString("address"),
-		RPCAddress: c.GlobalString("address-server-rpc"),
-		Anonymous:  c.GlobalBool("anonymous"),
-		TLS:        tls,
-		CertFile:   certFile,
-		KeyFile:    keyFile,
-		RateLimit:  c.GlobalInt("ratelimit"),
+		Address:          c.GlobalString("address"),
+		RPCAddress:       c.GlobalString("address-server-rpc"),
+		Anonymous:        c.GlobalBool("anonymous"),
+		TLS:              tls,
+		CertFile:         certFile,
+		KeyFile:          keyFile,
+		RateLimit:        c.GlobalInt("ratelimit"),
+		RateLimitTimeout: c.GlobalDuration("ratelimit-timeout"),
 	}
 }
 
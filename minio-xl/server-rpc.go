@@ -20,6 +20,8 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"sort"
+	"time"
 
 	"github.com/minio/minio-xl/pkg/probe"
 )
@@ -38,8 +40,32 @@ func (s *serverRPCService) MemStats(r *http.Request, arg *ServerArg, rep *MemSta
 	return nil
 }
 
+// DiskStats reports the statistics of the last sample of
+// globalDiskSampler, sorted by device name.
 func (s *serverRPCService) DiskStats(r *http.Request, arg *ServerArg, rep *DiskStatsRep) error {
-	rep.Disks = []string{"/mnt/disk1", "/mnt/disk2", "/mnt/disk3", "/mnt/disk4", "/mnt/disk5", "/mnt/disk6"}
+	stats := globalDiskSampler.Stats()
+	rep.Disks = make([]string, 0, len(stats))
+	for name := range stats {
+		rep.Disks = append(rep.Disks, name)
+	}
+	sort.Strings(rep.Disks)
+	ms := func(d time.Duration) float64 { return d.Seconds() * 1000 }
+	rep.Stats = make([]DiskStat, 0, len(stats))
+	for _, name := range rep.Disks {
+		st := stats[name]
+		rep.Stats = append(rep.Stats, DiskStat{
+			Name:             st.Name,
+			ReadIOPS:         st.ReadIOPS,
+			WriteIOPS:        st.WriteIOPS,
+			ReadBytesPerSec:  st.ReadBytesPerSec,
+			WriteBytesPerSec: st.WriteBytesPerSec,
+			ReadAwaitMs:      ms(st.ReadAwait),
+			WriteAwaitMs:     ms(st.WriteAwait),
+			AwaitMs:          ms(st.Await),
+			Util:             st.Util,
+			InFlight:         st.InFlight,
+		})
+	}
 	return nil
 }
 
@@ -63,6 +89,11 @@ func (s *serverRPCService) NetStats(r *http.Request, arg *ServerArg, rep *NetSta
 	return nil
 }
 
+func (s *serverRPCService) TicketMasterStats(r *http.Request, arg *ServerArg, rep *TicketMasterStatsRep) error {
+	rep.Classes = globalTicketMaster.stats()
+	return nil
+}
+
 func (s *serverRPCService) Version(r *http.Request, arg *ServerArg, rep *VersionRep) error {
 	rep.Version = "0.0.1"
 	rep.BuildDate = minioXLVersion
@@ -0,0 +1,225 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// opClass classifies API operations for admission control, each class
+// has its own concurrency limit and wait queue.
+type opClass int
+
+const (
+	opRead opClass = iota
+	opWrite
+	opList
+	opMultipart
+	numOPClasses
+)
+
+var opClassNames = [numOPClasses]string{"read", "write", "list", "multipart"}
+
+func (c opClass) String() string {
+	return opClassNames[c]
+}
+
+// Share of the rate limit given to each class, in 1/8ths.
+var opClassShares = [numOPClasses]int{4, 2, 1, 1}
+
+const (
+	// tmQueueFactor - an operation class queues at most this many
+	// operations per concurrency slot
+	tmQueueFactor = 4
+	// tmQueueTimeout - queued operations waiting longer are rejected,
+	// slots are held for whole transfers so this is generous
+	tmQueueTimeout = time.Minute
+)
+
+// tmClass is the state of one operation class.
+type tmClass struct {
+	limit    int        // concurrent operations, 0 for no limit
+	maxQueue int        // queued operations
+	running  int        // admitted and not yet released
+	queue    *list.List // of chan struct{}, closed when admitted
+
+	admitted  uint64
+	rejected  uint64
+	timedOut  uint64
+	peakQueue int
+}
+
+// ticketMaster admits API operations, up to a concurrency limit per
+// operation class.  Operations over the limit wait in a bounded queue
+// for up to a deadline and are rejected with SlowDown if it is full or
+// the deadline passes.
+type ticketMaster struct {
+	mutex   sync.Mutex
+	timeout time.Duration
+	classes [numOPClasses]*tmClass
+}
+
+// newTicketMaster returns a ticket master sharing rateLimit concurrent
+// operations among the operation classes, 0 admits everything.
+func newTicketMaster(rateLimit int) *ticketMaster {
+	tm := &ticketMaster{timeout: tmQueueTimeout}
+	for i := range tm.classes {
+		tm.classes[i] = &tmClass{queue: list.New()}
+	}
+	tm.setRateLimit(rateLimit)
+	return tm
+}
+
+// setRateLimit changes the limits, operations already admitted or queued
+// are not affected.
+func (tm *ticketMaster) setRateLimit(rateLimit int) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	limits := classLimits(rateLimit)
+	for i, class := range tm.classes {
+		class.limit = limits[i]
+		class.maxQueue = class.limit * tmQueueFactor
+	}
+}
+
+// classLimits shares rateLimit among the operation classes by
+// opClassShares, 0 is no limit.  Every class gets at least one slot, so
+// limits below numOPClasses are raised to numOPClasses, otherwise the
+// total never exceeds rateLimit.
+func classLimits(rateLimit int) (limits [numOPClasses]int) {
+	if rateLimit <= 0 {
+		return limits
+	}
+	if rateLimit < int(numOPClasses) {
+		rateLimit = int(numOPClasses)
+	}
+	total := 0
+	for i := range limits {
+		limits[i] = rateLimit * opClassShares[i] / 8
+		if limits[i] < 1 {
+			limits[i] = 1
+		}
+		total += limits[i]
+	}
+	// Rounding small shares up overshoots, the largest classes give up
+	// the excess.
+	for ; total > rateLimit; total-- {
+		largest := 0
+		for i := range limits {
+			if limits[i] > limits[largest] {
+				largest = i
+			}
+		}
+		limits[largest]--
+	}
+	return limits
+}
+
+// setQueueTimeout changes how long operations wait in the queue, zero
+// restores the default.
+func (tm *ticketMaster) setQueueTimeout(timeout time.Duration) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	if timeout <= 0 {
+		timeout = tmQueueTimeout
+	}
+	tm.timeout = timeout
+}
+
+// admit blocks until an operation of class may proceed, it returns false
+// if the operation is rejected.  Every admitted operation must be
+// released.
+func (tm *ticketMaster) admit(c opClass) bool {
+	tm.mutex.Lock()
+	class := tm.classes[c]
+	if class.limit == 0 || (class.running < class.limit && class.queue.Len() == 0) {
+		class.running++
+		class.admitted++
+		tm.mutex.Unlock()
+		return true
+	}
+	if class.queue.Len() >= class.maxQueue {
+		class.rejected++
+		tm.mutex.Unlock()
+		return false
+	}
+	proceedCh := make(chan struct{})
+	elem := class.queue.PushBack(proceedCh)
+	if class.queue.Len() > class.peakQueue {
+		class.peakQueue = class.queue.Len()
+	}
+	timeout := tm.timeout
+	tm.mutex.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-proceedCh:
+		return true
+	case <-timer.C:
+	}
+
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	select {
+	case <-proceedCh:
+		// admitted while timing out
+		return true
+	default:
+	}
+	class.queue.Remove(elem)
+	class.timedOut++
+	return false
+}
+
+// release ends an admitted operation of class, its slot goes to the
+// longest waiting operation.
+func (tm *ticketMaster) release(c opClass) {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	class := tm.classes[c]
+	class.running--
+	for (class.limit == 0 || class.running < class.limit) && class.queue.Len() > 0 {
+		proceedCh := class.queue.Remove(class.queue.Front()).(chan struct{})
+		class.running++
+		class.admitted++
+		close(proceedCh)
+	}
+}
+
+// stats returns the state and counters of all operation classes.
+func (tm *ticketMaster) stats() []TicketMasterClassStats {
+	tm.mutex.Lock()
+	defer tm.mutex.Unlock()
+	var stats []TicketMasterClassStats
+	for i, class := range tm.classes {
+		stats = append(stats, TicketMasterClassStats{
+			Class:     opClass(i).String(),
+			Limit:     class.limit,
+			MaxQueue:  class.maxQueue,
+			Running:   class.running,
+			Queued:    class.queue.Len(),
+			PeakQueue: class.peakQueue,
+			Admitted:  class.admitted,
+			Rejected:  class.rejected,
+			TimedOut:  class.timedOut,
+		})
+	}
+	return stats
+}
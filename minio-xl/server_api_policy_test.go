@@ -0,0 +1,227 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "gopkg.in/check.v1"
+)
+
+type BucketPolicySuite struct{}
+
+var _ = Suite(&BucketPolicySuite{})
+
+// readOnlyPolicy allows anonymous downloads of public/ and listing it.
+const readOnlyPolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [
+		{
+			"Effect": "Allow",
+			"Principal": {"AWS": ["*"]},
+			"Action": ["s3:ListBucket"],
+			"Resource": "arn:aws:s3:::photos",
+			"Condition": {"StringLike": {"s3:prefix": "public/*"}}
+		},
+		{
+			"Effect": "Allow",
+			"Principal": "*",
+			"Action": "s3:GetObject",
+			"Resource": "arn:aws:s3:::photos/public/*"
+		},
+		{
+			"Effect": "Deny",
+			"Principal": "*",
+			"Action": "s3:GetObject",
+			"Resource": "arn:aws:s3:::photos/public/private-*"
+		},
+		{
+			"Effect": "Allow",
+			"Principal": "*",
+			"Action": "s3:PutObject",
+			"Resource": "arn:aws:s3:::photos/uploads/*",
+			"Condition": {"StringEquals": {"aws:Referer": ["https://example.com/upload"]}}
+		}
+	]
+}`
+
+func (s *BucketPolicySuite) TestParseBucketPolicy(c *C) {
+	policy, err := parseBucketPolicy("photos", []byte(readOnlyPolicy))
+	c.Assert(err, IsNil)
+	c.Assert(policy.Statements, HasLen, 4)
+	c.Assert(policy.Statements[0].Principal.AWS, DeepEquals, policyStringSet{"*"})
+	c.Assert(policy.Statements[1].Principal.AWS, DeepEquals, policyStringSet{"*"})
+	c.Assert(policy.Statements[1].Actions, DeepEquals, policyStringSet{"s3:GetObject"})
+
+	malformed := []string{
+		``,
+		`not json`,
+		`{"Version": "2012-10-17"}`,
+		`{"Version": "2000-01-01", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::photos/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Maybe", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::photos/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::1:root"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::photos/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:PutBucketPolicy", "Resource": "arn:aws:s3:::photos"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::other/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::photosx/*"}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::photos/*", "Condition": {"IpAddress": {"aws:SourceIp": "10.0.0.0/8"}}}]}`,
+		`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::photos/*", "Condition": {"StringEquals": {"s3:x-amz-acl": "public-read"}}}]}`,
+	}
+	for _, data := range malformed {
+		_, err := parseBucketPolicy("photos", []byte(data))
+		c.Assert(err, NotNil, Commentf("%s", data))
+		c.Assert(err.ToGoError(), Equals, errMalformedPolicy)
+	}
+
+	large := make([]byte, maxBucketPolicySize+1)
+	_, err = parseBucketPolicy("photos", large)
+	c.Assert(err.ToGoError(), Equals, errMalformedPolicy)
+}
+
+func (s *BucketPolicySuite) TestStringLike(c *C) {
+	c.Assert(stringLike("public/a/b.jpg", "public/*"), Equals, true)
+	c.Assert(stringLike("public", "public/*"), Equals, false)
+	c.Assert(stringLike("a.jpg", "?.jpg"), Equals, true)
+	c.Assert(stringLike("ab.jpg", "?.jpg"), Equals, false)
+	c.Assert(stringLike("x/y/z.png", "*/*.png"), Equals, true)
+	c.Assert(stringLike("anything", "*"), Equals, true)
+	c.Assert(stringLike("", "*"), Equals, true)
+	c.Assert(stringLike("abc", "abc"), Equals, true)
+	c.Assert(stringLike("abcd", "abc"), Equals, false)
+}
+
+func (s *BucketPolicySuite) TestIsAllowed(c *C) {
+	policy, err := parseBucketPolicy("photos", []byte(readOnlyPolicy))
+	c.Assert(err, IsNil)
+
+	none := map[string]string{}
+	// Prefix resources.
+	c.Assert(policy.isAllowed("s3:GetObject", "arn:aws:s3:::photos/public/a.jpg", none), Equals, true)
+	c.Assert(policy.isAllowed("s3:GetObject", "arn:aws:s3:::photos/secret/a.jpg", none), Equals, false)
+	c.Assert(policy.isAllowed("s3:DeleteObject", "arn:aws:s3:::photos/public/a.jpg", none), Equals, false)
+	// Deny overrides Allow.
+	c.Assert(policy.isAllowed("s3:GetObject", "arn:aws:s3:::photos/public/private-a.jpg", none), Equals, false)
+
+	// StringLike on s3:prefix, a missing key does not match.
+	c.Assert(policy.isAllowed("s3:ListBucket", "arn:aws:s3:::photos", map[string]string{"s3:prefix": "public/2016/"}), Equals, true)
+	c.Assert(policy.isAllowed("s3:ListBucket", "arn:aws:s3:::photos", map[string]string{"s3:prefix": "secret/"}), Equals, false)
+	c.Assert(policy.isAllowed("s3:ListBucket", "arn:aws:s3:::photos", none), Equals, false)
+
+	// StringEquals on aws:Referer.
+	c.Assert(policy.isAllowed("s3:PutObject", "arn:aws:s3:::photos/uploads/a.jpg", map[string]string{"aws:Referer": "https://example.com/upload"}), Equals, true)
+	c.Assert(policy.isAllowed("s3:PutObject", "arn:aws:s3:::photos/uploads/a.jpg", map[string]string{"aws:Referer": "https://example.com/other"}), Equals, false)
+
+	// Negated conditions hold for missing keys.
+	notLike, err := parseBucketPolicy("photos", []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:*", "Resource": ["arn:aws:s3:::photos", "arn:aws:s3:::photos/*"], "Condition": {"StringNotLike": {"aws:UserAgent": "*bot*"}}}]}`))
+	c.Assert(err, IsNil)
+	c.Assert(notLike.isAllowed("s3:DeleteObject", "arn:aws:s3:::photos/a", none), Equals, true)
+	c.Assert(notLike.isAllowed("s3:ListBucket", "arn:aws:s3:::photos", map[string]string{"aws:UserAgent": "curl"}), Equals, true)
+	c.Assert(notLike.isAllowed("s3:GetObject", "arn:aws:s3:::photos/a", map[string]string{"aws:UserAgent": "googlebot"}), Equals, false)
+}
+
+func (s *BucketPolicySuite) TestGetPolicyAction(c *C) {
+	testCases := []struct {
+		method, url            string
+		bucket, object, action string
+	}{
+		{"GET", "/", "", "", ""},
+		{"GET", "/photos", "photos", "", "s3:ListBucket"},
+		{"HEAD", "/photos/", "photos", "", "s3:ListBucket"},
+		{"GET", "/photos?uploads", "photos", "", "s3:ListBucketMultipartUploads"},
+		{"GET", "/photos?location", "photos", "", ""},
+		{"GET", "/photos?policy", "photos", "", ""},
+		{"PUT", "/photos", "photos", "", ""},
+		{"GET", "/photos/a/b.jpg", "photos", "a/b.jpg", "s3:GetObject"},
+		{"HEAD", "/photos/a.jpg", "photos", "a.jpg", "s3:GetObject"},
+		{"GET", "/photos/a.jpg?acl", "photos", "a.jpg", ""},
+		{"GET", "/photos/a.jpg?uploadId=1", "photos", "a.jpg", "s3:ListMultipartUploadParts"},
+		{"PUT", "/photos/a.jpg", "photos", "a.jpg", "s3:PutObject"},
+		{"PUT", "/photos/a.jpg?partNumber=1&uploadId=1", "photos", "a.jpg", "s3:PutObject"},
+		{"POST", "/photos/a.jpg?uploads", "photos", "a.jpg", "s3:PutObject"},
+		{"DELETE", "/photos/a.jpg?uploadId=1", "photos", "a.jpg", "s3:AbortMultipartUpload"},
+		{"DELETE", "/photos/a.jpg", "photos", "a.jpg", "s3:DeleteObject"},
+	}
+	for _, testCase := range testCases {
+		req, err := http.NewRequest(testCase.method, "http://localhost"+testCase.url, nil)
+		c.Assert(err, IsNil)
+		bucket, object, action := getPolicyAction(req)
+		c.Assert([]string{bucket, object, action}, DeepEquals, []string{testCase.bucket, testCase.object, testCase.action}, Commentf("%s %s", testCase.method, testCase.url))
+	}
+
+	req, err := http.NewRequest("GET", "http://localhost/photos?prefix=public/&max-keys=10", nil)
+	c.Assert(err, IsNil)
+	req.Header.Set("Referer", "https://example.com")
+	req.Header.Set("User-Agent", "curl")
+	c.Assert(getPolicyConditions(req), DeepEquals, map[string]string{
+		"s3:prefix":     "public/",
+		"s3:max-keys":   "10",
+		"aws:Referer":   "https://example.com",
+		"aws:UserAgent": "curl",
+	})
+}
+
+func (s *BucketPolicySuite) TestBucketPolicyStore(c *C) {
+	root, e := ioutil.TempDir(os.TempDir(), "policy-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+	defer SetAuthConfigPath(customConfigPath)
+	SetAuthConfigPath(root)
+
+	store := newBucketPolicyStore()
+	_, _, err := store.Get("photos")
+	c.Assert(err.ToGoError(), Equals, errNoSuchBucketPolicy)
+	c.Assert(store.Remove("photos").ToGoError(), Equals, errNoSuchBucketPolicy)
+
+	c.Assert(store.Set("photos", []byte("{}")).ToGoError(), Equals, errMalformedPolicy)
+	c.Assert(store.Set("photos", []byte(readOnlyPolicy)), IsNil)
+	policy, data, err := store.Get("photos")
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, readOnlyPolicy)
+	c.Assert(policy.Statements, HasLen, 4)
+
+	// Policies persist, a bad policy file does not affect the others.
+	policyPath, err := getBucketPolicyPath()
+	c.Assert(err, IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(policyPath, "broken.json"), []byte("{"), 0600), IsNil)
+	store = newBucketPolicyStore()
+	_, data, err = store.Get("photos")
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, readOnlyPolicy)
+	_, _, err = store.Get("broken")
+	c.Assert(err.ToGoError(), Equals, errNoSuchBucketPolicy)
+	c.Assert(store.Remove("broken"), IsNil)
+
+	// Anonymous requests.
+	req, e := http.NewRequest("GET", "http://localhost/photos/public/a.jpg", nil)
+	c.Assert(e, IsNil)
+	c.Assert(isAllowedAnonymous(store, req), Equals, true)
+	req, e = http.NewRequest("PUT", "http://localhost/photos/public/a.jpg", nil)
+	c.Assert(e, IsNil)
+	c.Assert(isAllowedAnonymous(store, req), Equals, false)
+	req, e = http.NewRequest("GET", "http://localhost/other/public/a.jpg", nil)
+	c.Assert(e, IsNil)
+	c.Assert(isAllowedAnonymous(store, req), Equals, false)
+
+	c.Assert(store.Remove("photos"), IsNil)
+	_, _, err = store.Get("photos")
+	c.Assert(err.ToGoError(), Equals, errNoSuchBucketPolicy)
+	req, e = http.NewRequest("GET", "http://localhost/photos/public/a.jpg", nil)
+	c.Assert(e, IsNil)
+	c.Assert(isAllowedAnonymous(store, req), Equals, false)
+}
@@ -80,7 +80,6 @@ func (s *MyAPIXLCacheSuite) SetUpSuite(c *C) {
 
 	minioAPI := getNewAPI(false)
 	httpHandler := getAPIHandler(false, minioAPI)
-	go startTM(minioAPI)
 	testAPIXLCacheServer = httptest.NewServer(httpHandler)
 }
 
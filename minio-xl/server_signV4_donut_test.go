@@ -99,7 +99,6 @@ func (s *MyAPISignatureV4Suite) SetUpSuite(c *C) {
 
 	minioAPI := getNewAPI(false)
 	httpHandler := getAPIHandler(false, minioAPI)
-	go startTM(minioAPI)
 	testSignatureV4Server = httptest.NewServer(httpHandler)
 }
 
@@ -438,6 +437,87 @@ func (s *MyAPISignatureV4Suite) TestNotImplemented(c *C) {
 
 }
 
+func (s *MyAPISignatureV4Suite) TestBucketPolicy(c *C) {
+	request, err := s.newRequest("PUT", testSignatureV4Server.URL+"/policy-bucket", 0, nil)
+	c.Assert(err, IsNil)
+
+	client := http.Client{}
+	response, err := client.Do(request)
+	c.Assert(err, IsNil)
+	c.Assert(response.StatusCode, Equals, http.StatusOK)
+
+	buffer := bytes.NewReader([]byte("hello world"))
+	request, err = s.newRequest("PUT", testSignatureV4Server.URL+"/policy-bucket/public/object", int64(buffer.Len()), buffer)
+	c.Assert(err, IsNil)
+
+	response, err = client.Do(request)
+	c.Assert(err, IsNil)
+	c.Assert(response.StatusCode, Equals, http.StatusOK)
+
+	// no policy, anonymous access is denied
+	response, err = client.Get(testSignatureV4Server.URL + "/policy-bucket/public/object")
+	c.Assert(err, IsNil)
+	verifyError(c, response, "AccessDenied", "Access Denied.", http.StatusForbidden)
+
+	request, err = s.newRequest("GET", testSignatureV4Server.URL+"/policy-bucket?policy", 0, nil)
+	c.Assert(err, IsNil)
+	response, err = client.Do(request)
+	c.Assert(err, IsNil)
+	verifyError(c, response, "NoSuchBucketPolicy", "The bucket policy does not exist.", http.StatusNotFound)
+
+	policy := []byte(`{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject", "Resource": "arn:aws:s3:::policy-bucket/public/*"}]}`)
+	buffer = bytes.NewReader([]byte("{}"))
+	request, err = s.newRequest("PUT", testSignatureV4Server.URL+"/policy-bucket?policy", int64(buffer.Len()), buffer)
+	c.Assert(err, IsNil)
+	response, err = client.Do(request)
+	c.Assert(err, IsNil)
+	verifyError(c, response, "MalformedPolicy", "The bucket policy is not valid JSON or uses an unsupported feature.", http.StatusBadRequest)
+
+	buffer = bytes.NewReader(policy)
+	request, err = s.newRequest("PUT", testSignatureV4Server.URL+"/policy-bucket?policy", int64(buffer.Len()), buffer)
+	c.Assert(err, IsNil)
+	response, err = client.Do(request)
+	c.Assert(err, IsNil)
+	c.Assert(response.StatusCode, Equals, http.StatusNoContent)
+
+	request, err = s.newRequest("GET", testSignatureV4Server.URL+"/policy-bucket?policy", 0, nil)
+	c.Assert(err, IsNil)
+	response, err = client.Do(request)
+	c.Assert(err, IsNil)
+	c.Assert(response.StatusCode, Equals, http.StatusOK)
+	responseBody, err := ioutil.ReadAll(response.Body)
+	c.Assert(err, IsNil)
+	c.Assert(responseBody, DeepEquals, policy)
+
+	// the policy allows anonymous downloads of public/ only
+	response, err = client.Get(testSignatureV4Server.URL + "/policy-bucket/public/object")
+	c.Assert(err, IsNil)
+	c.Assert(response.StatusCode, Equals, http.StatusOK)
+	responseBody, err = ioutil.ReadAll(response.Body)
+	c.Assert(err, IsNil)
+	c.Assert(responseBody, DeepEquals, []byte("hello world"))
+
+	request, err = http.NewRequest("PUT", testSignatureV4Server.URL+"/policy-bucket/public/other", bytes.NewReader([]byte("hello")))
+	c.Assert(err, IsNil)
+	response, err = client.Do(request)
+	c.Assert(err, IsNil)
+	verifyError(c, response, "AccessDenied", "Access Denied.", http.StatusForbidden)
+
+	response, err = client.Get(testSignatureV4Server.URL + "/policy-bucket?policy")
+	c.Assert(err, IsNil)
+	verifyError(c, response, "AccessDenied", "Access Denied.", http.StatusForbidden)
+
+	request, err = s.newRequest("DELETE", testSignatureV4Server.URL+"/policy-bucket?policy", 0, nil)
+	c.Assert(err, IsNil)
+	response, err = client.Do(request)
+	c.Assert(err, IsNil)
+	c.Assert(response.StatusCode, Equals, http.StatusNoContent)
+
+	response, err = client.Get(testSignatureV4Server.URL + "/policy-bucket/public/object")
+	c.Assert(err, IsNil)
+	verifyError(c, response, "AccessDenied", "Access Denied.", http.StatusForbidden)
+}
+
 func (s *MyAPISignatureV4Suite) TestHeader(c *C) {
 	request, err := s.newRequest("GET", testSignatureV4Server.URL+"/bucket/object", 0, nil)
 	c.Assert(err, IsNil)
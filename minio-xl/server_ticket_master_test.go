@@ -0,0 +1,136 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type TicketMasterSuite struct{}
+
+var _ = Suite(&TicketMasterSuite{})
+
+// waitQueued waits until n operations of class are queued.
+func waitQueued(c *C, tm *ticketMaster, class opClass, n int) {
+	for i := 0; i < 1000; i++ {
+		if tm.stats()[class].Queued == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	c.Fatalf("%s queue never reached %d", class, n)
+}
+
+func (s *TicketMasterSuite) TestLimits(c *C) {
+	tm := newTicketMaster(16)
+	var limits []int
+	for _, stats := range tm.stats() {
+		limits = append(limits, stats.Limit)
+		c.Assert(stats.MaxQueue, Equals, stats.Limit*tmQueueFactor)
+	}
+	c.Assert(limits, DeepEquals, []int{8, 4, 2, 2})
+
+	// Every class gets a slot, the total does not exceed the limit
+	// unless it is below one slot per class.
+	for rateLimit, want := range map[int][]int{
+		1: {1, 1, 1, 1},
+		4: {1, 1, 1, 1},
+		5: {2, 1, 1, 1},
+		7: {3, 1, 1, 1},
+		9: {4, 2, 1, 1},
+	} {
+		tm.setRateLimit(rateLimit)
+		limits = nil
+		for _, stats := range tm.stats() {
+			limits = append(limits, stats.Limit)
+		}
+		c.Assert(limits, DeepEquals, want)
+	}
+
+	// No limit admits everything.
+	tm.setRateLimit(0)
+	for i := 0; i < 100; i++ {
+		c.Assert(tm.admit(opWrite), Equals, true)
+	}
+	c.Assert(tm.stats()[opWrite].Running, Equals, 100)
+	for i := 0; i < 100; i++ {
+		tm.release(opWrite)
+	}
+	c.Assert(tm.stats()[opWrite].Running, Equals, 0)
+}
+
+func (s *TicketMasterSuite) TestQueue(c *C) {
+	tm := newTicketMaster(1)
+	c.Assert(tm.admit(opRead), Equals, true)
+	// Classes are independent.
+	c.Assert(tm.admit(opList), Equals, true)
+
+	// Over the limit operations wait, in order.
+	admitted := make(chan int, tmQueueFactor)
+	for i := 0; i < tmQueueFactor; i++ {
+		go func(i int) {
+			if tm.admit(opRead) {
+				admitted <- i
+			}
+		}(i)
+		waitQueued(c, tm, opRead, i+1)
+	}
+	// The queue is full.
+	c.Assert(tm.admit(opRead), Equals, false)
+
+	stats := tm.stats()[opRead]
+	c.Assert(stats.Running, Equals, 1)
+	c.Assert(stats.Queued, Equals, tmQueueFactor)
+	c.Assert(stats.PeakQueue, Equals, tmQueueFactor)
+	c.Assert(stats.Rejected, Equals, uint64(1))
+
+	for i := 0; i < tmQueueFactor; i++ {
+		tm.release(opRead)
+		c.Assert(<-admitted, Equals, i)
+	}
+	tm.release(opRead)
+	stats = tm.stats()[opRead]
+	c.Assert(stats.Running, Equals, 0)
+	c.Assert(stats.Queued, Equals, 0)
+	c.Assert(stats.Admitted, Equals, uint64(1+tmQueueFactor))
+}
+
+func (s *TicketMasterSuite) TestTimeout(c *C) {
+	tm := newTicketMaster(1)
+	tm.setQueueTimeout(10 * time.Millisecond)
+	c.Assert(tm.admit(opMultipart), Equals, true)
+	c.Assert(tm.admit(opMultipart), Equals, false)
+	stats := tm.stats()[opMultipart]
+	c.Assert(stats.TimedOut, Equals, uint64(1))
+	c.Assert(stats.Queued, Equals, 0)
+
+	// A timed out operation does not take a slot.
+	tm.release(opMultipart)
+	c.Assert(tm.admit(opMultipart), Equals, true)
+	tm.release(opMultipart)
+
+	tm.setQueueTimeout(0)
+	c.Assert(tm.timeout, Equals, tmQueueTimeout)
+}
+
+func (s *TicketMasterSuite) TestSlowDown(c *C) {
+	err := getErrorCode(SlowDown)
+	c.Assert(err.Code, Equals, "SlowDown")
+	c.Assert(err.HTTPStatusCode, Equals, 503)
+}
@@ -19,12 +19,17 @@ package main
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/minio/minio-xl/pkg/probe"
+	"github.com/minio/minio-xl/pkg/scsi"
 )
 
-// isUsable provides a comprehensive way of knowing if the provided mountPath is mounted and writable
-func isUsable(mountPath string) (bool, *probe.Error) {
+// isUsable provides a comprehensive way of knowing if the provided mountPath is mounted and writable.
+// With qualify set the export must also pass scsi.Qualify with its default thresholds: filesystem
+// type, free space and inodes, throughput and fsync latency.  Where Qualify is not supported only
+// the write test is done.
+func isUsable(mountPath string, qualify bool) (bool, *probe.Error) {
 	_, e := os.Stat(mountPath)
 	if e != nil {
 		e := os.MkdirAll(mountPath, 0700)
@@ -43,5 +48,23 @@ func isUsable(mountPath string) (bool, *probe.Error) {
 	if e := os.Remove(testFileName); e != nil {
 		return false, probe.NewError(e)
 	}
+	if !qualify {
+		return true, nil
+	}
+
+	report, e := scsi.Qualify(mountPath, scsi.DefaultQualifyOptions)
+	if e == scsi.ErrQualifyNotSupported {
+		return true, nil
+	}
+	if e != nil {
+		return false, probe.NewError(e)
+	}
+	if !report.Pass() {
+		var failed []string
+		for _, check := range report.Failed() {
+			failed = append(failed, check.Name+" ("+check.Detail+")")
+		}
+		return false, probe.Errorf("%s does not qualify as an export: %s", mountPath, strings.Join(failed, ", "))
+	}
 	return true, nil
 }
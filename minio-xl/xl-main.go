@@ -31,12 +31,16 @@ var (
 			Name:        "make",
 			Description: "make a xl",
 			Action:      makeXLMain,
+			Flags:       []cli.Flag{qualifyFlag},
 			CustomHelpTemplate: `NAME:
   minio-xl xl {{.Name}} - {{.Description}}
 
 USAGE:
-  minio-xl xl {{.Name}} XL-NAME [DISKS...]
+  minio-xl xl {{.Name}} [--qualify] XL-NAME [DISKS...]
 
+FLAGS:
+  {{range .Flags}}{{.}}
+  {{end}}
 EXAMPLES:
   1. Make a xl with 4 exports
       $ minio-xl xl {{.Name}} mongodb-backup /mnt/export1 /mnt/export2 /mnt/export3 /mnt/export4
@@ -45,6 +49,9 @@ EXAMPLES:
       $ minio-xl xl {{.Name}} operational-data /mnt/export1 /mnt/export2 /mnt/export3 /mnt/export4 /mnt/export5 \
        /mnt/export6 /mnt/export7 /mnt/export8 /mnt/export9 /mnt/export10 /mnt/export11 \
        /mnt/export12 /mnt/export13 /mnt/export14 /mnt/export15 /mnt/export16
+
+  3. Make a xl with 4 exports, rejecting exports which fail the filesystem checks or the disk benchmark
+      $ minio-xl xl {{.Name}} --qualify mongodb-backup /mnt/export1 /mnt/export2 /mnt/export3 /mnt/export4
 `,
 		},
 	}
@@ -68,7 +75,7 @@ func makeXLMain(c *cli.Context) {
 	}
 	var disks []string
 	for _, disk := range c.Args().Tail() {
-		if _, err := isUsable(disk); err != nil {
+		if _, err := isUsable(disk, c.Bool("qualify")); err != nil {
 			Fatalln(err.Trace())
 		}
 		disks = append(disks, disk)
@@ -18,6 +18,8 @@
 package probe
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -29,34 +31,6 @@ import (
 	"github.com/dustin/go-humanize"
 )
 
-var (
-	// Root path to the project's source.
-	rootPath string
-	// App specific info to be included  reporting.
-	appInfo map[string]string
-)
-
-// Init initializes probe. It is typically called once from the main()
-// function or at least from any source file placed at the top level
-// source directory.
-func Init() {
-	// Root path is automatically determined from the calling function's source file location.
-	// Catch the calling function's source file path.
-	_, file, _, _ := runtime.Caller(1)
-	// Save the directory alone.
-	rootPath = filepath.Dir(file)
-
-	appInfo = make(map[string]string)
-}
-
-// SetAppInfo sets app speific key:value to report additionally during call trace dump.
-// Eg. SetAppInfo("ReleaseTag", "RELEASE_42_0")
-//     SetAppInfo("Version", "42.0")
-//     SetAppInfo("Commit", "00611fb")
-func SetAppInfo(key, value string) {
-	appInfo[key] = value
-}
-
 // GetSysInfo returns useful system statistics.
 func GetSysInfo() map[string]string {
 	host, err := os.Hostname()
@@ -87,11 +61,28 @@ type TracePoint struct {
 }
 
 // Error implements tracing error functionality.
+//
+// Creating and tracing an error is cheap, it only records program
+// counters.  They are resolved into CallTrace, and SysInfo is collected,
+// when the error is printed or marshalled, or by Resolve.
 type Error struct {
 	lock      sync.RWMutex
 	Cause     error             `json:"cause,omitempty"`
 	CallTrace []TracePoint      `json:"trace,omitempty"`
 	SysInfo   map[string]string `json:"sysinfo,omitempty"`
+
+	// frames are the recorded trace points, CallTrace holds the
+	// resolved prefix of them.
+	frames []frame
+	// scope of the library which created the error, if any.
+	scope *Scope
+}
+
+// frame is a trace point which is not resolved yet.
+type frame struct {
+	pcs  [3]uintptr // The caller, and room for inlined frames.
+	n    int
+	tags []string
 }
 
 // NewError function instantiates an error probe for tracing.
@@ -112,14 +103,33 @@ type Error struct {
 //           log.Fatalln(err.Trace())
 //     }
 //
+//
+// Causes wrapped with fmt.Errorf("...: %w", err) stay visible to
+// errors.Is and errors.As.  A *Error, bare or wrapped by WrapError, is
+// not wrapped again, its call trace is continued instead.
 func NewError(e error) *Error {
 	if e == nil {
 		return nil
 	}
-	Err := Error{lock: sync.RWMutex{}, Cause: e, CallTrace: []TracePoint{}, SysInfo: GetSysInfo()}
+	if perr, ok := UnwrapError(e); ok {
+		if perr == nil {
+			return nil
+		}
+		perr.lock.Lock()
+		defer perr.lock.Unlock()
+		return perr.trace() // Register the NewError's caller.
+	}
+	Err := &Error{Cause: e}
 	return Err.trace() // Skip NewError and only instead register the NewError's caller.
 }
 
+// Errorf is a shorthand for NewError(fmt.Errorf(format, a...)), the %w
+// verb wraps its argument as the cause.
+func Errorf(format string, a ...interface{}) *Error {
+	Err := &Error{Cause: fmt.Errorf(format, a...)}
+	return Err.trace()
+}
+
 // Trace records the point at which it is invoked.
 // Stack traces are important for debugging purposes.
 func (e *Error) Trace(fields ...string) *Error {
@@ -139,20 +149,62 @@ func (e *Error) trace(fields ...string) *Error {
 	if e == nil {
 		return nil
 	}
-	pc, file, line, _ := runtime.Caller(2)
-	function := runtime.FuncForPC(pc).Name()
-	_, function = filepath.Split(function)
-	file = strings.TrimPrefix(file, rootPath+string(os.PathSeparator)) // trims project's root path.
-	tp := TracePoint{}
-	if len(fields) > 0 {
-		tp = TracePoint{Line: line, Filename: file, Function: function, Env: map[string][]string{"Tags": fields}}
-	} else {
-		tp = TracePoint{Line: line, Filename: file, Function: function}
-	}
-	e.CallTrace = append(e.CallTrace, tp)
+	f := frame{tags: fields}
+	// Skip runtime.Callers, trace and its caller.
+	f.n = runtime.Callers(3, f.pcs[:])
+	e.frames = append(e.frames, f)
 	return e
 }
 
+// resolve converts the frames recorded since the last call into
+// CallTrace. Must be called with e.lock held.
+func (e *Error) resolve() {
+	for i := len(e.CallTrace); i < len(e.frames); i++ {
+		f := e.frames[i]
+		frame, _ := runtime.CallersFrames(f.pcs[:f.n]).Next()
+		_, function := filepath.Split(frame.Function)
+		file := trimPath(frame.File) // trims project's root path.
+		tp := TracePoint{Line: frame.Line, Filename: file, Function: function}
+		if len(f.tags) > 0 {
+			tp.Env = map[string][]string{"Tags": f.tags}
+		}
+		e.CallTrace = append(e.CallTrace, tp)
+	}
+	if e.SysInfo == nil {
+		e.SysInfo = GetSysInfo()
+	}
+}
+
+// Resolve fills CallTrace and SysInfo, which are otherwise only filled
+// when the error is printed or marshalled.
+func (e *Error) Resolve() *Error {
+	if e == nil {
+		return nil
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.resolve()
+	return e
+}
+
+// MarshalJSON resolves the error before marshalling it.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.resolve()
+	cause := ""
+	if e.Cause != nil {
+		cause = e.Cause.Error()
+	}
+	return json.Marshal(struct {
+		Cause     string            `json:"cause,omitempty"`
+		CallTrace []TracePoint      `json:"trace,omitempty"`
+		SysInfo   map[string]string `json:"sysinfo,omitempty"`
+	}{cause, e.CallTrace, e.SysInfo})
+}
+
 // Untrace erases last known trace entry.
 func (e *Error) Untrace() *Error {
 	if e == nil {
@@ -161,11 +213,14 @@ func (e *Error) Untrace() *Error {
 	e.lock.Lock()
 	defer e.lock.Unlock()
 
-	l := len(e.CallTrace)
+	l := len(e.frames)
 	if l == 0 {
 		return nil
 	}
-	e.CallTrace = e.CallTrace[:l-1]
+	e.frames = e.frames[:l-1]
+	if len(e.CallTrace) > l-1 {
+		e.CallTrace = e.CallTrace[:l-1]
+	}
 	return e
 }
 
@@ -177,14 +232,47 @@ func (e *Error) ToGoError() error {
 	return e.Cause
 }
 
+// Error returns the message with call trace like String, so that
+// printing a *Error with fmt is unchanged.  Use ToGoError().Error() for
+// the message of the cause alone.
+func (e *Error) Error() string {
+	return e.String()
+}
+
+// Unwrap returns the cause, errors.Is and errors.As look through a
+// *Error into its cause.
+func (e *Error) Unwrap() error {
+	return e.ToGoError()
+}
+
+// Is reports whether target is a *Error with the same cause, two
+// errors created from the same cause are equivalent.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok || e == nil || t == nil || t.Cause == nil {
+		return false
+	}
+	return errors.Is(e.Cause, t.Cause)
+}
+
+// As finds the first error in the chain of the cause that matches
+// target, see errors.As.
+func (e *Error) As(target interface{}) bool {
+	if e == nil || e.Cause == nil {
+		return false
+	}
+	return errors.As(e.Cause, target)
+}
+
 // String returns error message.
 func (e *Error) String() string {
 	if e == nil || e.Cause == nil {
 		return "<nil>"
 	}
-	e.lock.RLock()
-	defer e.lock.RUnlock()
+	e.lock.Lock()
+	defer e.lock.Unlock()
 
+	e.resolve()
 	if e.Cause != nil {
 		str := e.Cause.Error()
 		callLen := len(e.CallTrace)
@@ -200,8 +288,13 @@ func (e *Error) String() string {
 
 		str += "\n "
 
-		for key, value := range appInfo {
-			str += key + ":" + value + " | "
+		for _, kv := range sortedInfo(GetAppInfo()) {
+			str += kv + " | "
+		}
+		if e.scope != nil {
+			for _, kv := range sortedInfo(e.scope.GetInfo()) {
+				str += e.scope.name + "." + kv + " | "
+			}
 		}
 
 		str += "Host:" + e.SysInfo["host.name"] + " | "
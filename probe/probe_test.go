@@ -17,10 +17,15 @@
 package probe_test
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"runtime"
+	"strings"
 	"testing"
 
-	"github.com/minio/minio/pkg/probe"
+	"github.com/minio/minio-xl/pkg/probe"
 	. "gopkg.in/check.v1"
 )
 
@@ -62,3 +67,111 @@ func (s *MySuite) TestWrappedError(c *C) {
 	_, ok := probe.UnwrapError(e)
 	c.Assert(ok, Equals, true)
 }
+
+func (s *MySuite) TestErrorsIs(c *C) {
+	es := testDummy2()
+	c.Assert(errors.Is(es, os.ErrNotExist), Equals, true)
+	c.Assert(errors.Is(probe.WrapError(es), os.ErrNotExist), Equals, true)
+	c.Assert(errors.Is(es, os.ErrExist), Equals, false)
+
+	// fmt.Errorf wrapping, with NewError and Errorf.
+	_, e := os.Stat("this-file-cannot-exit")
+	es = probe.NewError(fmt.Errorf("loading config: %w", e))
+	c.Assert(errors.Is(es, os.ErrNotExist), Equals, true)
+	es = probe.Errorf("loading config: %w", e).Trace("config.json")
+	c.Assert(errors.Is(es, os.ErrNotExist), Equals, true)
+	c.Assert(es.ToGoError().Error(), Equals, "loading config: "+e.Error())
+	c.Assert(es.Error(), Equals, es.String())
+	c.Assert(es.Resolve().CallTrace, HasLen, 2)
+
+	// Errors of the same cause are equivalent.
+	cause := errors.New("Help Needed")
+	c.Assert(errors.Is(probe.NewError(cause), probe.NewError(cause)), Equals, true)
+	c.Assert(errors.Is(probe.NewError(cause), probe.NewError(errors.New("Help Needed"))), Equals, false)
+}
+
+func (s *MySuite) TestErrorsAs(c *C) {
+	es := testDummy2()
+
+	var pathErr *os.PathError
+	c.Assert(errors.As(es, &pathErr), Equals, true)
+	c.Assert(pathErr.Path, Equals, "this-file-cannot-exit")
+
+	var perr *probe.Error
+	c.Assert(errors.As(probe.WrapError(es), &perr), Equals, true)
+	c.Assert(perr, Equals, es)
+	c.Assert(errors.Unwrap(es), Equals, es.ToGoError())
+}
+
+func (s *MySuite) TestTraceUntrace(c *C) {
+	es := testDummy2()
+	c.Assert(es.Resolve().CallTrace, HasLen, 3)
+	c.Assert(es.CallTrace[0].Function, Equals, "probe_test.testDummy0")
+	c.Assert(es.CallTrace[2].Env["Tags"], DeepEquals, []string{"DummyTag2"})
+	c.Assert(es.Trace("Top").Resolve().CallTrace, HasLen, 4)
+	c.Assert(es.Untrace().CallTrace, HasLen, 3)
+	c.Assert(es.Untrace().Resolve().CallTrace, HasLen, 2)
+	c.Assert(es.Trace().Trace().Resolve().CallTrace, HasLen, 4)
+
+	// NewError continues the trace of a probe error instead of wrapping it.
+	again := probe.NewError(probe.WrapError(es))
+	c.Assert(again, Equals, es)
+	c.Assert(again.Resolve().CallTrace, HasLen, 5)
+	c.Assert(again.CallTrace[4].Function, Equals, "probe_test.(*MySuite).TestTraceUntrace")
+	c.Assert(again.ToGoError(), FitsTypeOf, &os.PathError{})
+}
+
+func (s *MySuite) TestLazyResolve(c *C) {
+	es := testDummy2()
+	c.Assert(es.CallTrace, HasLen, 0)
+	c.Assert(es.SysInfo, IsNil)
+
+	str := es.String()
+	c.Assert(strings.Contains(str, "DummyTag1"), Equals, true)
+	c.Assert(es.CallTrace, HasLen, 3)
+	c.Assert(es.SysInfo["host.os"], Equals, runtime.GOOS)
+
+	data, e := json.Marshal(es.Trace("json"))
+	c.Assert(e, IsNil)
+	var decoded struct {
+		Cause     string             `json:"cause"`
+		CallTrace []probe.TracePoint `json:"trace"`
+		SysInfo   map[string]string  `json:"sysinfo"`
+	}
+	c.Assert(json.Unmarshal(data, &decoded), IsNil)
+	c.Assert(decoded.Cause, Equals, es.ToGoError().Error())
+	c.Assert(decoded.CallTrace, HasLen, 4)
+	c.Assert(decoded.CallTrace[3].Env["Tags"], DeepEquals, []string{"json"})
+	c.Assert(decoded.SysInfo["host.arch"], Equals, runtime.GOARCH)
+}
+
+func (s *MySuite) TestScope(c *C) {
+	probe.SetAppInfo("Version", "42.0")
+	scope := probe.NewScope("library", "library-tag")
+	scope.SetInfo("Version", "1.0")
+
+	es := scope.NewError(errors.New("Help Needed")).Trace("call")
+	report := probe.NewErrorReport(es, "")
+	c.Assert(report.Scope, Equals, "library")
+	c.Assert(report.ScopeInfo, DeepEquals, map[string]string{"Version": "1.0"})
+	c.Assert(report.AppInfo["Version"], Equals, "42.0")
+	c.Assert(report.Tags, DeepEquals, []string{"call", "library-tag"})
+	c.Assert(strings.Contains(es.String(), "Version:42.0 | library.Version:1.0"), Equals, true)
+
+	// Errors of another scope keep it.
+	other := probe.NewScope("other")
+	es = other.NewError(probe.WrapError(es))
+	c.Assert(probe.NewErrorReport(es, "").Scope, Equals, "library")
+
+	es = scope.Errorf("wrapped: %w", os.ErrNotExist)
+	c.Assert(errors.Is(es, os.ErrNotExist), Equals, true)
+	c.Assert(probe.NewErrorReport(es, "").Scope, Equals, "library")
+}
+
+func BenchmarkNewErrorTrace(b *testing.B) {
+	cause := errors.New("Object not found")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		probe.NewError(cause).Trace().Trace("bucket", "object").Trace()
+	}
+}
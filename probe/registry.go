@@ -0,0 +1,169 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// registry holds the process wide settings of probe, all of them are
+// safe for concurrent use.
+var registry = struct {
+	lock    sync.RWMutex
+	appInfo map[string]string // App specific info to be included in reporting.
+	roots   []string          // Source roots trimmed from file names, longest first.
+	modules []string          // Module paths file names are trimmed to.
+}{
+	appInfo: make(map[string]string),
+}
+
+// Init initializes probe. It is typically called once from the main()
+// function or at least from any source file placed at the top level
+// source directory.
+//
+// The directory of the calling source file is added as a source root,
+// and the module paths of the binary's build info as modules, see AddRoot
+// and AddModule.
+func Init() {
+	// Root path is automatically determined from the calling function's source file location.
+	// Catch the calling function's source file path.
+	_, file, _, _ := runtime.Caller(1)
+	// Save the directory alone.
+	AddRoot(filepath.Dir(file))
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if info.Main.Path != "" {
+			AddModule(info.Main.Path)
+		}
+		for _, dep := range info.Deps {
+			AddModule(dep.Path)
+		}
+	}
+}
+
+// SetAppInfo sets app speific key:value to report additionally during call trace dump.
+// Eg. SetAppInfo("ReleaseTag", "RELEASE_42_0")
+//
+//	SetAppInfo("Version", "42.0")
+//	SetAppInfo("Commit", "00611fb")
+func SetAppInfo(key, value string) {
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	registry.appInfo[key] = value
+}
+
+// GetAppInfo returns a copy of the info set with SetAppInfo.
+func GetAppInfo() map[string]string {
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+	info := make(map[string]string, len(registry.appInfo))
+	for key, value := range registry.appInfo {
+		info[key] = value
+	}
+	return info
+}
+
+// AddRoot adds a source root directory, file names of trace points
+// below it are shown relative to it.
+func AddRoot(root string) {
+	root = strings.TrimSuffix(filepath.ToSlash(root), "/")
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	for _, r := range registry.roots {
+		if r == root {
+			return
+		}
+	}
+	registry.roots = append(registry.roots, root)
+	// Longest first, the innermost root wins.
+	sort.SliceStable(registry.roots, func(i, j int) bool {
+		return len(registry.roots[i]) > len(registry.roots[j])
+	})
+}
+
+// AddModule adds a Go module path.  File names of trace points inside
+// the module, in the module cache, a vendor or GOPATH directory, are
+// shown as the module path followed by the file within the module,
+// without version.
+//
+// Eg. /root/go/pkg/mod/github.com/minio/minio-go@v1.0.0/api.go is shown
+// as github.com/minio/minio-go/api.go.
+func AddModule(path string) {
+	path = strings.Trim(path, "/")
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	for _, m := range registry.modules {
+		if m == path {
+			return
+		}
+	}
+	registry.modules = append(registry.modules, path)
+	sort.SliceStable(registry.modules, func(i, j int) bool {
+		return len(registry.modules[i]) > len(registry.modules[j])
+	})
+}
+
+// trimPath shortens the file name of a trace point with the source roots
+// and modules.  Source roots are tried first.
+func trimPath(file string) string {
+	file = filepath.ToSlash(file)
+
+	registry.lock.RLock()
+	defer registry.lock.RUnlock()
+
+	for _, root := range registry.roots {
+		if strings.HasPrefix(file, root+"/") {
+			return file[len(root)+1:]
+		}
+	}
+	for _, module := range registry.modules {
+		i := strings.LastIndex(file, "/"+module)
+		if i < 0 {
+			continue
+		}
+		rest := file[i+1+len(module):]
+		switch {
+		case strings.HasPrefix(rest, "@"):
+			// Module cache, drop the version.
+			slash := strings.Index(rest, "/")
+			if slash < 0 {
+				continue
+			}
+			rest = rest[slash:]
+		case !strings.HasPrefix(rest, "/"):
+			// Only a prefix of another path element.
+			continue
+		}
+		return module + rest
+	}
+	return file
+}
+
+// sortedInfo returns the key:value pairs of info sorted by key.
+func sortedInfo(info map[string]string) []string {
+	var kvs []string
+	for key, value := range info {
+		kvs = append(kvs, key+":"+value)
+	}
+	sort.Strings(kvs)
+	return kvs
+}
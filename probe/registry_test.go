@@ -0,0 +1,75 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"strconv"
+	"sync"
+
+	. "gopkg.in/check.v1"
+)
+
+// Run by the Test function of probe_test.
+type RegistrySuite struct{}
+
+var _ = Suite(&RegistrySuite{})
+
+func (s *RegistrySuite) TestTrimPath(c *C) {
+	AddRoot("/src/minio-xl/")
+	AddRoot("/src/minio-xl/vendor/github.com/minio/sha256-simd")
+	AddModule("github.com/minio/minio-go")
+	AddModule("github.com/minio/minio")
+
+	testCases := []struct {
+		file, trimmed string
+	}{
+		{"/src/minio-xl/server-main.go", "server-main.go"},
+		{"/src/minio-xl/pkg/xl/xl.go", "pkg/xl/xl.go"},
+		// The innermost root wins.
+		{"/src/minio-xl/vendor/github.com/minio/sha256-simd/sha256.go", "sha256.go"},
+		// Module cache, without version.
+		{"/root/go/pkg/mod/github.com/minio/minio-go@v1.0.0/api.go", "github.com/minio/minio-go/api.go"},
+		{"/root/go/pkg/mod/github.com/minio/minio@v0.0.0-20160101/pkg/probe/probe.go", "github.com/minio/minio/pkg/probe/probe.go"},
+		// GOPATH.
+		{"/go/src/github.com/minio/minio/main.go", "github.com/minio/minio/main.go"},
+		// github.com/minio/minio is only a prefix of minio-mc.
+		{"/go/src/github.com/minio/minio-mc/main.go", "/go/src/github.com/minio/minio-mc/main.go"},
+		{"/elsewhere/main.go", "/elsewhere/main.go"},
+	}
+	for _, testCase := range testCases {
+		c.Assert(trimPath(testCase.file), Equals, testCase.trimmed)
+	}
+}
+
+func (s *RegistrySuite) TestConcurrentAppInfo(c *C) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			key := "Key-" + strconv.Itoa(i)
+			SetAppInfo(key, strconv.Itoa(i))
+			AddRoot("/src/" + key)
+			NewError(errHelp).Resolve()
+		}(i)
+	}
+	wg.Wait()
+	info := GetAppInfo()
+	for i := 0; i < 8; i++ {
+		c.Assert(info["Key-"+strconv.Itoa(i)], Equals, strconv.Itoa(i))
+	}
+}
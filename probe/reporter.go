@@ -0,0 +1,469 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// timeNow is replaced by tests.
+var timeNow = time.Now
+
+// ErrorReport is the structured form of an error sent to a Reporter.
+type ErrorReport struct {
+	Time      time.Time         `json:"time"`
+	Message   string            `json:"message,omitempty"`
+	Cause     string            `json:"cause"`
+	Type      string            `json:"type,omitempty"`
+	CallTrace []TracePoint      `json:"trace,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+	AppInfo   map[string]string `json:"appinfo,omitempty"`
+	// Scope of the library which created the error, see NewScope.
+	Scope     string            `json:"scope,omitempty"`
+	ScopeInfo map[string]string `json:"scopeinfo,omitempty"`
+	SysInfo   map[string]string `json:"sysinfo,omitempty"`
+	// Repeated counts the duplicates of this report suppressed by a
+	// Filter, Dropped the reports dropped by its rate limit, since the
+	// last report sent.
+	Repeated int `json:"repeated,omitempty"`
+	Dropped  int `json:"dropped,omitempty"`
+}
+
+// NewErrorReport resolves err into a report, msg describes what failed.
+// Tags are collected from all trace points and the scope of err, AppInfo
+// from SetAppInfo.
+func NewErrorReport(err *Error, msg string) ErrorReport {
+	err.Resolve()
+
+	err.lock.RLock()
+	defer err.lock.RUnlock()
+
+	report := ErrorReport{
+		Time:      timeNow().UTC(),
+		Message:   msg,
+		CallTrace: append([]TracePoint(nil), err.CallTrace...),
+		SysInfo:   err.SysInfo,
+	}
+	if err.Cause != nil {
+		report.Cause = err.Cause.Error()
+		report.Type = reflect.TypeOf(err.Cause).String()
+	}
+	for _, tp := range err.CallTrace {
+		report.Tags = append(report.Tags, tp.Env["Tags"]...)
+	}
+	report.AppInfo = GetAppInfo()
+	if err.scope != nil {
+		report.Scope = err.scope.name
+		report.ScopeInfo = err.scope.GetInfo()
+		report.Tags = append(report.Tags, err.scope.tags...)
+	}
+	return report
+}
+
+// key identifies duplicate reports: the same cause reported from the
+// same point, the most recent trace point.
+func (r ErrorReport) key() string {
+	if len(r.CallTrace) == 0 {
+		return r.Cause
+	}
+	tp := r.CallTrace[len(r.CallTrace)-1]
+	return fmt.Sprintf("%s\x00%s:%d", r.Cause, tp.Filename, tp.Line)
+}
+
+// Reporter sends error reports to a sink.
+type Reporter interface {
+	Report(report ErrorReport) error
+	Close() error
+}
+
+// reportQueueSize is the number of reports queued for each registered
+// Reporter, reports arriving while its queue is full are dropped.
+const reportQueueSize = 256
+
+// ErrReportDropped - the queue of a registered Reporter was full.
+var ErrReportDropped = errors.New("Report dropped, reporter queue is full")
+
+// reporterQueue calls a Reporter from its own goroutine, so that a slow
+// sink never blocks the callers of Report.
+type reporterQueue struct {
+	reporter Reporter
+	reports  chan ErrorReport
+	done     chan struct{}
+}
+
+func newReporterQueue(r Reporter) *reporterQueue {
+	q := &reporterQueue{
+		reporter: r,
+		reports:  make(chan ErrorReport, reportQueueSize),
+		done:     make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+func (q *reporterQueue) run() {
+	defer close(q.done)
+	for report := range q.reports {
+		// Errors of the sink have no caller to go to.
+		q.reporter.Report(report)
+	}
+}
+
+// send queues report, it returns false if the queue is full.
+func (q *reporterQueue) send(report ErrorReport) bool {
+	select {
+	case q.reports <- report:
+		return true
+	default:
+		return false
+	}
+}
+
+// close delivers the queued reports and closes the Reporter.
+func (q *reporterQueue) close() error {
+	close(q.reports)
+	<-q.done
+	return q.reporter.Close()
+}
+
+var (
+	reportersLock sync.RWMutex
+	reporters     []*reporterQueue
+)
+
+// AddReporter registers a Reporter used by Report.
+func AddReporter(r Reporter) {
+	reportersLock.Lock()
+	defer reportersLock.Unlock()
+	reporters = append(reporters, newReporterQueue(r))
+}
+
+// CloseReporters delivers the queued reports, then closes and
+// unregisters all reporters, it returns the first error.
+func CloseReporters() error {
+	reportersLock.Lock()
+	defer reportersLock.Unlock()
+
+	var err error
+	for _, q := range reporters {
+		if e := q.close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	reporters = nil
+	return err
+}
+
+// Report queues err for all registered reporters and returns, each
+// Reporter is called from a goroutine of its own.  Errors of reporters
+// are lost, ErrReportDropped is returned if the queue of a reporter was
+// full.
+//
+//	probe.AddReporter(probe.NewFilter(fileReporter, probe.FilterOptions{}))
+//	...
+//	probe.Report(err.Trace(), "Unable to heal bucket")
+func Report(err *Error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	reportersLock.RLock()
+	defer reportersLock.RUnlock()
+	if len(reporters) == 0 {
+		return nil
+	}
+
+	report := NewErrorReport(err, msg)
+	var rerr error
+	for _, q := range reporters {
+		if !q.send(report) {
+			rerr = ErrReportDropped
+		}
+	}
+	return rerr
+}
+
+// FileReporter appends reports to a file as JSON lines.
+type FileReporter struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// NewFileReporter opens the named file for appending reports.
+func NewFileReporter(name string) (*FileReporter, error) {
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0640)
+	if err != nil {
+		return nil, err
+	}
+	return &FileReporter{file: file}, nil
+}
+
+// Report appends one line to the file.
+func (f *FileReporter) Report(report ErrorReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	// A single write keeps lines whole among concurrent writers.
+	_, err = f.file.Write(append(data, '\n'))
+	return err
+}
+
+// Close closes the file.
+func (f *FileReporter) Close() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.file.Close()
+}
+
+// WebhookReporter posts reports as JSON to an HTTP endpoint.
+type WebhookReporter struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookReporter posts reports to url with client, a client with a
+// 10 second timeout is used if client is nil.
+func NewWebhookReporter(url string, client *http.Client) *WebhookReporter {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &WebhookReporter{url: url, client: client}
+}
+
+// Report posts the report, any status other than 2xx is an error.
+func (w *WebhookReporter) Report(report ErrorReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s: %s", w.url, resp.Status)
+	}
+	return nil
+}
+
+// Close is a no-op.
+func (w *WebhookReporter) Close() error {
+	return nil
+}
+
+// FilterOptions configure a Filter, the zero value is the default.
+type FilterOptions struct {
+	// Window suppresses duplicates of a report for this long, one
+	// minute if zero.  The count of suppressed duplicates is sent
+	// with the first duplicate after the window, or with the last
+	// duplicate by a periodic flush if no other one arrives.
+	Window time.Duration
+	// Rate is the maximum number of reports per second, with bursts
+	// of as many reports, 10 if zero.
+	Rate int
+}
+
+// minFlushInterval bounds how often a Filter looks for suppressed
+// duplicates to flush, whatever its window.
+const minFlushInterval = time.Second
+
+// filterEntry is the state of one key of a Filter.
+type filterEntry struct {
+	sent       time.Time
+	suppressed int
+	latest     ErrorReport // most recent duplicate, sent by flush
+}
+
+// Filter is a Reporter deduplicating and rate limiting the reports sent
+// to another Reporter.
+type Filter struct {
+	lock    sync.Mutex
+	next    Reporter
+	opts    FilterOptions
+	seen    map[string]*filterEntry
+	tokens  float64
+	last    time.Time
+	dropped int
+
+	// sendLock serializes the calls to next by Report and flush.
+	sendLock sync.Mutex
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewFilter returns a Filter sending reports to next.  A goroutine
+// flushes suppressed duplicates until Close.
+func NewFilter(next Reporter, opts FilterOptions) *Filter {
+	if opts.Window <= 0 {
+		opts.Window = time.Minute
+	}
+	if opts.Rate <= 0 {
+		opts.Rate = 10
+	}
+	f := &Filter{
+		next:   next,
+		opts:   opts,
+		seen:   make(map[string]*filterEntry),
+		tokens: float64(opts.Rate),
+		last:   timeNow(),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go f.flushLoop()
+	return f
+}
+
+// Report sends the report unless it is a duplicate or over the rate
+// limit.  Suppressed reports are not an error.
+func (f *Filter) Report(report ErrorReport) error {
+	f.lock.Lock()
+	now := timeNow()
+
+	key := report.key()
+	entry, ok := f.seen[key]
+	if ok && now.Sub(entry.sent) < f.opts.Window {
+		entry.suppressed++
+		entry.latest = report
+		f.lock.Unlock()
+		return nil
+	}
+
+	if !f.take(now) {
+		f.dropped++
+		f.lock.Unlock()
+		return nil
+	}
+
+	if !ok {
+		entry = &filterEntry{}
+		f.seen[key] = entry
+	}
+	report.Repeated = entry.suppressed
+	report.Dropped = f.dropped
+	entry.sent = now
+	entry.suppressed = 0
+	entry.latest = ErrorReport{}
+	f.dropped = 0
+	f.expire(now)
+	f.lock.Unlock()
+
+	f.sendLock.Lock()
+	defer f.sendLock.Unlock()
+	return f.next.Report(report)
+}
+
+// take takes a token of the rate limit, it returns false if there is
+// none left.  Must be called with f.lock held.
+func (f *Filter) take(now time.Time) bool {
+	f.tokens += now.Sub(f.last).Seconds() * float64(f.opts.Rate)
+	if f.tokens > float64(f.opts.Rate) {
+		f.tokens = float64(f.opts.Rate)
+	}
+	f.last = now
+	if f.tokens < 1 {
+		return false
+	}
+	f.tokens--
+	return true
+}
+
+// flush sends the most recent duplicate of every key whose window is
+// over with the count of suppressed duplicates, as no further duplicate
+// may come to carry it.  Keys over the rate limit wait for the next
+// flush.
+func (f *Filter) flush() error {
+	f.lock.Lock()
+	now := timeNow()
+	var reports []ErrorReport
+	for _, entry := range f.seen {
+		if entry.suppressed == 0 || now.Sub(entry.sent) < f.opts.Window {
+			continue
+		}
+		if !f.take(now) {
+			break
+		}
+		report := entry.latest
+		report.Repeated = entry.suppressed - 1
+		report.Dropped = f.dropped
+		entry.sent = now
+		entry.suppressed = 0
+		entry.latest = ErrorReport{}
+		f.dropped = 0
+		reports = append(reports, report)
+	}
+	f.expire(now)
+	f.lock.Unlock()
+
+	f.sendLock.Lock()
+	defer f.sendLock.Unlock()
+	var err error
+	for _, report := range reports {
+		if e := f.next.Report(report); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+func (f *Filter) flushLoop() {
+	defer close(f.done)
+	interval := f.opts.Window
+	if interval < minFlushInterval {
+		interval = minFlushInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Errors of the sink have no caller to go to.
+			f.flush()
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+// expire forgets the keys without duplicates to report, their window is
+// over.  Must be called with f.lock held.
+func (f *Filter) expire(now time.Time) {
+	for key, entry := range f.seen {
+		if entry.suppressed == 0 && now.Sub(entry.sent) >= f.opts.Window {
+			delete(f.seen, key)
+		}
+	}
+}
+
+// Close flushes the suppressed duplicates whose window is over, stops
+// flushing and closes the next Reporter.
+func (f *Filter) Close() error {
+	close(f.stop)
+	<-f.done
+	f.flush()
+	return f.next.Close()
+}
@@ -0,0 +1,219 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+// Run by the Test function of probe_test.
+type ReporterSuite struct{}
+
+var _ = Suite(&ReporterSuite{})
+
+// recorder is a Reporter keeping the reports in memory.
+type recorder struct {
+	reports []ErrorReport
+}
+
+func (r *recorder) Report(report ErrorReport) error {
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+func (r *recorder) Close() error { return nil }
+
+// clock is a manual timeNow.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) set() func() {
+	timeNow = func() time.Time { return c.now }
+	return func() { timeNow = time.Now }
+}
+
+var errHelp = errors.New("Help Needed")
+
+func reportA() *Error { return NewError(errHelp).Trace("a") }
+func reportB() *Error { return NewError(errHelp).Trace("b") }
+
+func (s *ReporterSuite) TestErrorReport(c *C) {
+	Init()
+	SetAppInfo("Commit-ID", "7390cc957239")
+	report := NewErrorReport(reportA().Trace("top"), "Unable to help")
+	c.Assert(report.Message, Equals, "Unable to help")
+	c.Assert(report.Cause, Equals, "Help Needed")
+	c.Assert(report.Type, Equals, "*errors.errorString")
+	c.Assert(report.Tags, DeepEquals, []string{"a", "top"})
+	c.Assert(report.CallTrace, HasLen, 3)
+	c.Assert(report.AppInfo["Commit-ID"], Equals, "7390cc957239")
+	c.Assert(report.SysInfo["host.os"], Not(Equals), "")
+}
+
+func (s *ReporterSuite) TestFilterDuplicates(c *C) {
+	clk := &clock{now: time.Unix(1000, 0)}
+	defer clk.set()()
+
+	rec := &recorder{}
+	f := NewFilter(rec, FilterOptions{Window: time.Minute, Rate: 100})
+	defer f.Close()
+	a := NewErrorReport(reportA(), "")
+	b := NewErrorReport(reportB(), "")
+	for i := 0; i < 5; i++ {
+		c.Assert(f.Report(a), IsNil)
+	}
+	// Same cause from another point is not a duplicate.
+	c.Assert(f.Report(b), IsNil)
+	c.Assert(rec.reports, HasLen, 2)
+
+	clk.now = clk.now.Add(time.Minute)
+	c.Assert(f.Report(a), IsNil)
+	c.Assert(rec.reports, HasLen, 3)
+	c.Assert(rec.reports[2].Repeated, Equals, 4)
+	c.Assert(f.Report(a), IsNil)
+	c.Assert(rec.reports, HasLen, 3)
+
+	// The burst ends, the flush reports the last duplicate once its
+	// window is over.
+	c.Assert(f.flush(), IsNil)
+	c.Assert(rec.reports, HasLen, 3)
+	clk.now = clk.now.Add(time.Minute)
+	c.Assert(f.flush(), IsNil)
+	c.Assert(rec.reports, HasLen, 4)
+	c.Assert(rec.reports[3].Repeated, Equals, 0)
+	c.Assert(f.flush(), IsNil)
+	c.Assert(rec.reports, HasLen, 4)
+}
+
+func (s *ReporterSuite) TestFilterRate(c *C) {
+	clk := &clock{now: time.Unix(1000, 0)}
+	defer clk.set()()
+
+	rec := &recorder{}
+	f := NewFilter(rec, FilterOptions{Window: time.Nanosecond, Rate: 2})
+	defer f.Close()
+	a := NewErrorReport(reportA(), "")
+	for i := 0; i < 5; i++ {
+		clk.now = clk.now.Add(time.Nanosecond)
+		c.Assert(f.Report(a), IsNil)
+	}
+	c.Assert(rec.reports, HasLen, 2)
+
+	clk.now = clk.now.Add(time.Second)
+	c.Assert(f.Report(a), IsNil)
+	c.Assert(rec.reports, HasLen, 3)
+	c.Assert(rec.reports[2].Dropped, Equals, 3)
+}
+
+func (s *ReporterSuite) TestFileReporter(c *C) {
+	root, e := ioutil.TempDir("", "probe-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(root)
+
+	name := filepath.Join(root, "errors.json")
+	r, e := NewFileReporter(name)
+	c.Assert(e, IsNil)
+	AddReporter(r)
+	c.Assert(Report(reportA(), "first"), IsNil)
+	c.Assert(Report(reportB(), "second"), IsNil)
+	c.Assert(Report(nil, "none"), IsNil)
+	c.Assert(CloseReporters(), IsNil)
+	c.Assert(Report(reportA(), "unregistered"), IsNil)
+
+	file, e := os.Open(name)
+	c.Assert(e, IsNil)
+	defer file.Close()
+	var messages []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var report ErrorReport
+		c.Assert(json.Unmarshal(scanner.Bytes(), &report), IsNil)
+		c.Assert(report.Cause, Equals, "Help Needed")
+		messages = append(messages, report.Message)
+	}
+	c.Assert(messages, DeepEquals, []string{"first", "second"})
+}
+
+// blockingReporter blocks in Report until release is closed.
+type blockingReporter struct {
+	release chan struct{}
+	count   int
+}
+
+func (r *blockingReporter) Report(report ErrorReport) error {
+	<-r.release
+	r.count++
+	return nil
+}
+
+func (r *blockingReporter) Close() error { return nil }
+
+func (s *ReporterSuite) TestReportQueue(c *C) {
+	r := &blockingReporter{release: make(chan struct{})}
+	AddReporter(r)
+	// Report does not wait for the stuck reporter, its queue fills up
+	// and further reports are dropped.
+	queued := 0
+	for ; queued <= reportQueueSize+1; queued++ {
+		if err := Report(reportA(), "queued"); err != nil {
+			c.Assert(err, Equals, ErrReportDropped)
+			break
+		}
+	}
+	c.Assert(queued >= reportQueueSize, Equals, true)
+	c.Assert(queued <= reportQueueSize+1, Equals, true)
+
+	// Closing delivers the queued reports.
+	close(r.release)
+	c.Assert(CloseReporters(), IsNil)
+	c.Assert(r.count, Equals, queued)
+}
+
+func (s *ReporterSuite) TestWebhookReporter(c *C) {
+	reports := make(chan ErrorReport, 1)
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var report ErrorReport
+		c.Check(r.Header.Get("Content-Type"), Equals, "application/json")
+		c.Check(json.NewDecoder(r.Body).Decode(&report), IsNil)
+		reports <- report
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	r := NewWebhookReporter(server.URL, nil)
+	c.Assert(r.Report(NewErrorReport(reportA(), "hook")), IsNil)
+	report := <-reports
+	c.Assert(report.Message, Equals, "hook")
+	c.Assert(report.Tags, DeepEquals, []string{"a"})
+
+	status = http.StatusInternalServerError
+	c.Assert(r.Report(NewErrorReport(reportA(), "hook")), NotNil)
+	<-reports
+	c.Assert(r.Close(), IsNil)
+}
@@ -0,0 +1,94 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Scope is the error context of a library.  Errors created through a
+// Scope carry its name, info and tags in addition to the application
+// info set with SetAppInfo, so a library never overwrites the settings
+// of the application using it.
+//
+//	var scope = probe.NewScope("minio-go", "client")
+//	...
+//	return scope.NewError(err)
+type Scope struct {
+	name string
+	tags []string
+
+	lock sync.RWMutex
+	info map[string]string
+}
+
+// NewScope returns a scope named name, tags are added to the tags of
+// every error of the scope.
+func NewScope(name string, tags ...string) *Scope {
+	return &Scope{name: name, tags: tags, info: make(map[string]string)}
+}
+
+// Name returns the name of the scope.
+func (s *Scope) Name() string {
+	return s.name
+}
+
+// SetInfo sets scope specific key:value, like SetAppInfo.
+func (s *Scope) SetInfo(key, value string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.info[key] = value
+}
+
+// GetInfo returns a copy of the info set with SetInfo.
+func (s *Scope) GetInfo() map[string]string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	info := make(map[string]string, len(s.info))
+	for key, value := range s.info {
+		info[key] = value
+	}
+	return info
+}
+
+// NewError is probe.NewError for errors of the scope.  A probe error of
+// another scope keeps its scope.
+func (s *Scope) NewError(e error) *Error {
+	if e == nil {
+		return nil
+	}
+	if perr, ok := UnwrapError(e); ok {
+		if perr == nil {
+			return nil
+		}
+		perr.lock.Lock()
+		defer perr.lock.Unlock()
+		if perr.scope == nil {
+			perr.scope = s
+		}
+		return perr.trace() // Register the NewError's caller.
+	}
+	Err := &Error{Cause: e, scope: s}
+	return Err.trace()
+}
+
+// Errorf is probe.Errorf for errors of the scope.
+func (s *Scope) Errorf(format string, a ...interface{}) *Error {
+	Err := &Error{Cause: fmt.Errorf(format, a...), scope: s}
+	return Err.trace()
+}
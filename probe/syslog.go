@@ -0,0 +1,54 @@
+// +build !windows,!plan9
+
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"encoding/json"
+	"log/syslog"
+)
+
+// SyslogReporter sends reports as JSON to syslog at error severity.
+type SyslogReporter struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogReporter connects to the syslog daemon at raddr over network,
+// or to the local daemon if network is empty.  tag is the program name
+// of the messages.
+func NewSyslogReporter(network, raddr, tag string) (*SyslogReporter, error) {
+	writer, err := syslog.Dial(network, raddr, syslog.LOG_ERR|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &SyslogReporter{writer: writer}, nil
+}
+
+// Report sends one message.
+func (s *SyslogReporter) Report(report ErrorReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return s.writer.Err(string(data))
+}
+
+// Close closes the connection to syslog.
+func (s *SyslogReporter) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,50 @@
+// +build !windows,!plan9
+
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package probe
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *ReporterSuite) TestSyslogReporter(c *C) {
+	conn, e := net.ListenPacket("udp", "127.0.0.1:0")
+	c.Assert(e, IsNil)
+	defer conn.Close()
+
+	r, e := NewSyslogReporter("udp", conn.LocalAddr().String(), "probe")
+	c.Assert(e, IsNil)
+	c.Assert(r.Report(NewErrorReport(reportA(), "syslog")), IsNil)
+	c.Assert(r.Close(), IsNil)
+
+	buf := make([]byte, 64*1024)
+	n, _, e := conn.ReadFrom(buf)
+	c.Assert(e, IsNil)
+	msg := string(buf[:n])
+	// <priority>timestamp hostname tag[pid]: message
+	c.Assert(strings.HasPrefix(msg, "<27>"), Equals, true)
+	i := strings.Index(msg, "]: ")
+	c.Assert(i > 0, Equals, true)
+	var report ErrorReport
+	c.Assert(json.Unmarshal([]byte(strings.TrimSpace(msg[i+3:])), &report), IsNil)
+	c.Assert(report.Message, Equals, "syslog")
+}
@@ -32,6 +32,8 @@ func UnwrapError(err error) (*Error, bool) {
 	switch e := err.(type) {
 	case *wrappedError:
 		return e.err, true
+	case *Error:
+		return e, true
 	default:
 		return nil, false
 	}
@@ -41,3 +43,11 @@ func UnwrapError(err error) (*Error, bool) {
 func (w *wrappedError) Error() string {
 	return w.err.String()
 }
+
+// Unwrap returns the wrapped *probe.Error.
+func (w *wrappedError) Unwrap() error {
+	if w.err == nil {
+		return nil
+	}
+	return w.err
+}
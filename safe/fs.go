@@ -0,0 +1,129 @@
+/*
+ * Minio Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safe
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// errUnnamedUnsupported - O_TMPFILE is not supported by the platform or
+// the filesystem.
+var errUnnamedUnsupported = errors.New("unnamed temporary files not supported")
+
+// file is the subset of *os.File used by this package.
+type file interface {
+	io.Writer
+	io.WriterAt
+	io.ReaderFrom
+	io.Seeker
+	Truncate(size int64) error
+	Chmod(mode os.FileMode) error
+	Name() string
+	Fd() uintptr
+	Sync() error
+	Close() error
+}
+
+// filesystem is the set of filesystem operations used by this package,
+// tests replace it to inject faults and record the order of operations.
+type filesystem interface {
+	MkdirAll(path string, perm os.FileMode) error
+	TempFile(dir, prefix string) (file, error)
+	OpenFile(name string, flag int, perm os.FileMode) (file, error)
+	Chmod(name string, mode os.FileMode) error
+	Stat(name string) (os.FileInfo, error)
+	Rename(oldpath, newpath string) error
+	Remove(name string) error
+	// OpenUnnamed creates an unnamed temporary file in dir, it returns
+	// errUnnamedUnsupported if this is not possible.
+	OpenUnnamed(dir string, perm os.FileMode) (file, error)
+	// Link gives the unnamed file f the name newname.
+	Link(f file, newname string) error
+	// SyncDir flushes the directory entries of dir to stable storage.
+	SyncDir(dir string) error
+}
+
+// osFS implements filesystem with the os package.
+type osFS struct{}
+
+func (osFS) MkdirAll(path string, perm os.FileMode) error { return os.MkdirAll(path, perm) }
+func (osFS) Chmod(name string, mode os.FileMode) error    { return os.Chmod(name, mode) }
+func (osFS) Stat(name string) (os.FileInfo, error)        { return os.Lstat(name) }
+func (osFS) Rename(oldpath, newpath string) error         { return os.Rename(oldpath, newpath) }
+func (osFS) Remove(name string) error                     { return os.Remove(name) }
+
+func (osFS) TempFile(dir, prefix string) (file, error) {
+	f, err := ioutil.TempFile(dir, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (osFS) OpenFile(name string, flag int, perm os.FileMode) (file, error) {
+	f, err := os.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (osFS) OpenUnnamed(dir string, perm os.FileMode) (file, error) {
+	f, err := openUnnamed(dir, perm)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (osFS) Link(f file, newname string) error { return linkUnnamed(f.Fd(), newname) }
+
+func (osFS) SyncDir(dir string) error {
+	// Directories cannot be opened for syncing on windows, renames
+	// are journaled by NTFS.
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err = d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
+}
+
+// tempName returns a random file name in dir starting with prefix.
+func tempName(dir, prefix string) (string, error) {
+	var rnd [8]byte
+	if _, err := rand.Read(rnd[:]); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, prefix+hex.EncodeToString(rnd[:])), nil
+}
+
+// fs is the filesystem used by CreateFile.
+var fs filesystem = osFS{}
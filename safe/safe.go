@@ -20,64 +20,243 @@ package safe
 
 import (
 	"errors"
-	"io/ioutil"
+	"io"
 	"os"
 	"path/filepath"
 )
 
+const (
+	// DefaultPrefix prefixes the names of temporary files.
+	DefaultPrefix = "$tmpfile."
+	// DefaultMode is the permission of created files.
+	DefaultMode os.FileMode = 0600
+	// DefaultDirMode is the permission of created parent directories.
+	DefaultDirMode os.FileMode = 0700
+)
+
 // File represents safe file descriptor.
 type File struct {
-	name    string
-	tmpfile *os.File
-	closed  bool
-	aborted bool
+	name      string
+	tmpfile   file
+	fs        filesystem
+	prefix    string
+	noSync    bool
+	anonymous bool // tmpfile has no name, see Options.Unnamed
+	txn       *Txn // set for files published by Txn.Commit
+	closed    bool
+	aborted   bool
+}
+
+// Options - optional behaviour of a safe File, the zero value is the
+// default used by CreateFile.
+type Options struct {
+	// NoSync disables fsync of the temporary file before the rename
+	// and of the parent directory after it. Only meant for filesystems
+	// where durability is meaningless, such as tmpfs.
+	NoSync bool
+
+	// Prefix of the temporary file name, DefaultPrefix if empty.
+	// Sweep must be given the same prefix to find leftovers.
+	Prefix string
+
+	// Mode of the created file, DefaultMode if zero.
+	Mode os.FileMode
+
+	// DirMode of missing parent directories, DefaultDirMode if zero.
+	DirMode os.FileMode
+
+	// Unnamed creates the temporary file with O_TMPFILE where the
+	// platform and filesystem support it, the file only gets a name
+	// on Close when it is complete.  Nothing is left behind by a
+	// crash before Close.  Falls back to a named temporary file.
+	Unnamed bool
+}
+
+// check returns an error if the File can no longer be modified.
+func (file *File) check(op string) error {
+	if file.aborted {
+		return errors.New(op + " on aborted file")
+	}
+	if file.closed {
+		return errors.New(op + " on closed file")
+	}
+	return nil
+}
+
+// abortOnError closes and removes the temporary file if err is not nil.
+func (file *File) abortOnError(err error) {
+	if err != nil {
+		file.tmpfile.Close()
+		file.removeTemp()
+		file.aborted = true
+	}
 }
 
 // Write writes len(b) bytes to the temporary File.  In case of error, the temporary file is removed.
 func (file *File) Write(b []byte) (n int, err error) {
-	if file.aborted {
-		err = errors.New("write on aborted file")
+	if err = file.check("write"); err != nil {
 		return
 	}
-	if file.closed {
-		err = errors.New("write on closed file")
+	defer func() { file.abortOnError(err) }()
+
+	n, err = file.tmpfile.Write(b)
+	return
+}
+
+// WriteAt writes len(b) bytes to the temporary File at offset off.  In case of error, the temporary file is removed.
+func (file *File) WriteAt(b []byte, off int64) (n int, err error) {
+	if err = file.check("writeat"); err != nil {
 		return
 	}
+	defer func() { file.abortOnError(err) }()
 
-	defer func() {
-		if err != nil {
-			os.Remove(file.tmpfile.Name())
-			file.aborted = true
-		}
-	}()
+	n, err = file.tmpfile.WriteAt(b, off)
+	return
+}
 
-	n, err = file.tmpfile.Write(b)
+// ReadFrom copies r to the temporary File until io.EOF, using the
+// sendfile or copy_file_range fast paths of *os.File where possible.
+// Errors reading r also leave the File incomplete, in case of any error
+// the temporary file is removed.
+func (file *File) ReadFrom(r io.Reader) (n int64, err error) {
+	if err = file.check("readfrom"); err != nil {
+		return
+	}
+	defer func() { file.abortOnError(err) }()
+
+	n, err = file.tmpfile.ReadFrom(r)
 	return
 }
 
-// Close closes the temporary File and renames to the named file.  In case of error, the temporary file is removed.
-func (file *File) Close() (err error) {
-	defer func() {
-		if err != nil {
-			os.Remove(file.tmpfile.Name())
-			file.aborted = true
-		}
-	}()
+// Seek sets the offset of the next Write on the temporary File.  In case of error, the temporary file is removed.
+func (file *File) Seek(offset int64, whence int) (ret int64, err error) {
+	if err = file.check("seek"); err != nil {
+		return
+	}
+	defer func() { file.abortOnError(err) }()
 
-	if file.aborted || file.closed {
+	ret, err = file.tmpfile.Seek(offset, whence)
+	return
+}
+
+// Truncate changes the size of the temporary File, the offset is not
+// changed.  In case of error, the temporary file is removed.
+func (file *File) Truncate(size int64) (err error) {
+	if err = file.check("truncate"); err != nil {
 		return
 	}
+	defer func() { file.abortOnError(err) }()
 
-	if err = file.tmpfile.Close(); err != nil {
+	err = file.tmpfile.Truncate(size)
+	return
+}
+
+// Sync commits the content of the temporary File to stable storage
+// without publishing it, Close does this anyway unless NoSync is set.
+// In case of error, the temporary file is removed.
+func (file *File) Sync() (err error) {
+	if err = file.check("sync"); err != nil {
 		return
 	}
+	defer func() { file.abortOnError(err) }()
 
-	err = os.Rename(file.tmpfile.Name(), file.name)
+	err = file.tmpfile.Sync()
+	return
+}
+
+// Close syncs and closes the temporary File, renames it to the named file
+// and syncs the parent directory so that the rename survives a crash.  In
+// case of error before the rename, the temporary file is removed.  An
+// error syncing the parent directory is returned after the rename, the
+// named file is in place but may not be durable.
+//
+// Files created by a Txn are only synced and closed, they are renamed by
+// Txn.Commit.
+func (file *File) Close() (err error) {
+	if file.aborted || file.closed {
+		return
+	}
 
+	if file.txn != nil {
+		if err = file.flush(); err != nil {
+			file.removeTemp()
+			file.aborted = true
+			return
+		}
+		file.closed = true
+		return
+	}
+
+	if err = file.commit(); err != nil {
+		file.removeTemp()
+		file.aborted = true
+		return
+	}
 	file.closed = true
+
+	if !file.noSync {
+		err = file.fs.SyncDir(filepath.Dir(file.name))
+	}
 	return
 }
 
+// flush syncs and closes the temporary file.
+func (file *File) flush() error {
+	if !file.noSync {
+		if err := file.tmpfile.Sync(); err != nil {
+			file.tmpfile.Close()
+			return err
+		}
+	}
+	return file.tmpfile.Close()
+}
+
+// commit flushes the temporary file and renames it to the named file.
+func (file *File) commit() error {
+	if file.anonymous {
+		return file.commitAnonymous()
+	}
+	if err := file.flush(); err != nil {
+		return err
+	}
+	return file.fs.Rename(file.tmpfile.Name(), file.name)
+}
+
+// commitAnonymous links an unnamed temporary file into its directory
+// and renames it to the named file.  linkat cannot replace an existing
+// file, the link gets a temporary name first.
+func (file *File) commitAnonymous() error {
+	if !file.noSync {
+		if err := file.tmpfile.Sync(); err != nil {
+			file.tmpfile.Close()
+			return err
+		}
+	}
+	tmpname, err := tempName(filepath.Dir(file.name), file.prefix+filepath.Base(file.name)+".")
+	if err == nil {
+		err = file.fs.Link(file.tmpfile, tmpname)
+	}
+	if cerr := file.tmpfile.Close(); err == nil && cerr != nil {
+		file.fs.Remove(tmpname)
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err = file.fs.Rename(tmpname, file.name); err != nil {
+		file.fs.Remove(tmpname)
+	}
+	return err
+}
+
+// removeTemp removes the temporary file, unnamed ones vanish on close.
+func (file *File) removeTemp() error {
+	if file.anonymous {
+		return nil
+	}
+	return file.fs.Remove(file.tmpfile.Name())
+}
+
 // Abort aborts the temporary File by closing and removing the temporary file.
 func (file *File) Abort() (err error) {
 	if file.aborted || file.closed {
@@ -85,7 +264,7 @@ func (file *File) Abort() (err error) {
 	}
 
 	file.tmpfile.Close()
-	err = os.Remove(file.tmpfile.Name())
+	err = file.removeTemp()
 	file.aborted = true
 	return
 }
@@ -97,28 +276,65 @@ func (file *File) Abort() (err error) {
 // and prefixed "$tmpfile" string.  While creating the temporary file,
 // missing parent directories are also created.  The temporary file is
 // removed if case of any intermediate failure.  Not removed temporary
-// files can be cleaned up by identifying them using "$tmpfile" prefix
-// string.
+// files are cleaned up by Sweep.
+//
+// Close fsyncs the temporary file before the rename and the parent
+// directory after it, see CreateFileWithOptions to disable this.
 func CreateFile(name string) (*File, error) {
+	return CreateFileWithOptions(name, Options{})
+}
+
+// CreateFileWithOptions is like CreateFile with optional behaviour.
+func CreateFileWithOptions(name string, opts Options) (*File, error) {
+	if opts.Prefix == "" {
+		opts.Prefix = DefaultPrefix
+	}
+	if opts.Mode == 0 {
+		opts.Mode = DefaultMode
+	}
+	if opts.DirMode == 0 {
+		opts.DirMode = DefaultDirMode
+	}
+
 	// ioutil.TempFile() fails if parent directory is missing.
 	// Create parent directory to avoid such error.
 	dname := filepath.Dir(name)
-	if err := os.MkdirAll(dname, 0700); err != nil {
+	if err := fs.MkdirAll(dname, opts.DirMode); err != nil {
 		return nil, err
 	}
 
+	safeFile := &File{name: name, fs: fs, prefix: opts.Prefix, noSync: opts.NoSync}
+	if opts.Unnamed {
+		tmpfile, err := fs.OpenUnnamed(dname, opts.Mode)
+		if err == nil {
+			// The mode given to open is subject to the umask.
+			if err = tmpfile.Chmod(opts.Mode); err != nil {
+				tmpfile.Close()
+				return nil, err
+			}
+			safeFile.tmpfile = tmpfile
+			safeFile.anonymous = true
+			return safeFile, nil
+		}
+		if err != errUnnamedUnsupported {
+			return nil, err
+		}
+	}
+
 	fname := filepath.Base(name)
-	tmpfile, err := ioutil.TempFile(dname, "$tmpfile."+fname+".")
+	tmpfile, err := fs.TempFile(dname, opts.Prefix+fname+".")
 	if err != nil {
 		return nil, err
 	}
 
-	if err = os.Chmod(tmpfile.Name(), 0600); err != nil {
-		if rerr := os.Remove(tmpfile.Name()); rerr != nil {
+	if err = fs.Chmod(tmpfile.Name(), opts.Mode); err != nil {
+		tmpfile.Close()
+		if rerr := fs.Remove(tmpfile.Name()); rerr != nil {
 			err = rerr
 		}
 		return nil, err
 	}
 
-	return &File{name: name, tmpfile: tmpfile}, nil
+	safeFile.tmpfile = tmpfile
+	return safeFile, nil
 }
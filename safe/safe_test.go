@@ -17,10 +17,16 @@
 package safe
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	. "gopkg.in/check.v1"
 )
@@ -67,3 +73,670 @@ func (s *MySuite) TestSafeAbort(c *C) {
 	_, err = os.Stat(filepath.Join(s.root, "purgefile"))
 	c.Assert(err, Not(IsNil))
 }
+
+// faultFS records the operations done through it and fails the ones
+// named in faults.
+type faultFS struct {
+	osFS
+	ops    []string
+	faults map[string]error
+	// crashAt fails operation number crashAt (counting from 1) and all
+	// following ones with errCrash, zero disables it.
+	crashAt int
+}
+
+var errCrash = errors.New("simulated crash")
+
+type faultFile struct {
+	file
+	fs *faultFS
+}
+
+func (f *faultFS) do(op string) error {
+	f.ops = append(f.ops, op)
+	if f.crashAt > 0 && len(f.ops) >= f.crashAt {
+		return errCrash
+	}
+	return f.faults[op]
+}
+
+func (f *faultFS) TempFile(dir, prefix string) (file, error) {
+	if err := f.do("tempfile"); err != nil {
+		return nil, err
+	}
+	tmpfile, err := f.osFS.TempFile(dir, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return faultFile{tmpfile, f}, nil
+}
+
+func (f *faultFS) OpenFile(name string, flag int, perm os.FileMode) (file, error) {
+	if err := f.do("open"); err != nil {
+		return nil, err
+	}
+	fd, err := f.osFS.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return faultFile{fd, f}, nil
+}
+
+func (f *faultFS) Rename(oldpath, newpath string) error {
+	if err := f.do("rename"); err != nil {
+		return err
+	}
+	return f.osFS.Rename(oldpath, newpath)
+}
+
+func (f *faultFS) Remove(name string) error {
+	if err := f.do("remove"); err != nil {
+		return err
+	}
+	return f.osFS.Remove(name)
+}
+
+func (f *faultFS) SyncDir(dir string) error {
+	if err := f.do("syncdir"); err != nil {
+		return err
+	}
+	return f.osFS.SyncDir(dir)
+}
+
+func (f faultFile) Write(b []byte) (int, error) {
+	if err := f.fs.do("write"); err != nil {
+		return 0, err
+	}
+	return f.file.Write(b)
+}
+
+func (f faultFile) WriteAt(b []byte, off int64) (int, error) {
+	if err := f.fs.do("writeat"); err != nil {
+		return 0, err
+	}
+	return f.file.WriteAt(b, off)
+}
+
+func (f faultFile) ReadFrom(r io.Reader) (int64, error) {
+	if err := f.fs.do("readfrom"); err != nil {
+		return 0, err
+	}
+	return f.file.ReadFrom(r)
+}
+
+func (f faultFile) Seek(offset int64, whence int) (int64, error) {
+	if err := f.fs.do("seek"); err != nil {
+		return 0, err
+	}
+	return f.file.Seek(offset, whence)
+}
+
+func (f faultFile) Truncate(size int64) error {
+	if err := f.fs.do("truncate"); err != nil {
+		return err
+	}
+	return f.file.Truncate(size)
+}
+
+func (f faultFile) Sync() error {
+	if err := f.fs.do("sync"); err != nil {
+		return err
+	}
+	return f.file.Sync()
+}
+
+func (f faultFile) Close() error {
+	// The file is always closed to not leak descriptors.
+	err := f.file.Close()
+	if ferr := f.fs.do("close"); ferr != nil {
+		return ferr
+	}
+	return err
+}
+
+// withFS runs fn with fs replaced by a faultFS failing faults.
+func withFS(faults map[string]error, fn func(*faultFS)) {
+	withFaultFS(&faultFS{faults: faults}, fn)
+}
+
+// withFaultFS runs fn with fs replaced by ffs.
+func withFaultFS(ffs *faultFS, fn func(*faultFS)) {
+	fs = ffs
+	defer func() { fs = osFS{} }()
+	fn(ffs)
+}
+
+func (s *MySuite) TestSafeDurableOrder(c *C) {
+	name := filepath.Join(s.root, "durable")
+	withFS(nil, func(ffs *faultFS) {
+		f, err := CreateFile(name)
+		c.Assert(err, IsNil)
+		_, err = f.Write([]byte("hello"))
+		c.Assert(err, IsNil)
+		c.Assert(f.Close(), IsNil)
+		c.Assert(strings.Join(ffs.ops, ","), Equals, "tempfile,write,sync,close,rename,syncdir")
+	})
+	data, err := ioutil.ReadFile(name)
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "hello")
+	c.Assert(os.Remove(name), IsNil)
+}
+
+func (s *MySuite) TestSafeNoSync(c *C) {
+	name := filepath.Join(s.root, "nosync")
+	withFS(nil, func(ffs *faultFS) {
+		f, err := CreateFileWithOptions(name, Options{NoSync: true})
+		c.Assert(err, IsNil)
+		c.Assert(f.Close(), IsNil)
+		c.Assert(strings.Join(ffs.ops, ","), Equals, "tempfile,close,rename")
+	})
+	c.Assert(os.Remove(name), IsNil)
+}
+
+func (s *MySuite) TestSafeFaults(c *C) {
+	errFault := errors.New("injected fault")
+	testCases := []struct {
+		fault   string
+		ops     string
+		renamed bool
+	}{
+		// Failing to sync the file must leave the named file untouched.
+		{"sync", "tempfile,write,sync,close,remove", false},
+		{"close", "tempfile,write,sync,close,remove", false},
+		{"rename", "tempfile,write,sync,close,rename,remove", false},
+		// The rename happened, the error is still reported.
+		{"syncdir", "tempfile,write,sync,close,rename,syncdir", true},
+	}
+	for _, testCase := range testCases {
+		name := filepath.Join(s.root, "fault-"+testCase.fault)
+		withFS(map[string]error{testCase.fault: errFault}, func(ffs *faultFS) {
+			f, err := CreateFile(name)
+			c.Assert(err, IsNil)
+			_, err = f.Write([]byte("hello"))
+			c.Assert(err, IsNil)
+			c.Assert(f.Close(), Equals, errFault)
+			c.Assert(strings.Join(ffs.ops, ","), Equals, testCase.ops)
+			// Further calls are no-ops.
+			c.Assert(f.Close(), IsNil)
+		})
+		_, err := os.Stat(name)
+		c.Assert(err == nil, Equals, testCase.renamed)
+		if testCase.renamed {
+			c.Assert(os.Remove(name), IsNil)
+		}
+		// No temporary file is left behind.
+		entries, err := ioutil.ReadDir(s.root)
+		c.Assert(err, IsNil)
+		c.Assert(entries, HasLen, 0)
+	}
+}
+
+func (s *MySuite) TestSafeWriteFault(c *C) {
+	errFault := errors.New("injected fault")
+	name := filepath.Join(s.root, "writefault")
+	withFS(map[string]error{"write": errFault}, func(ffs *faultFS) {
+		f, err := CreateFile(name)
+		c.Assert(err, IsNil)
+		_, err = f.Write([]byte("hello"))
+		c.Assert(err, Equals, errFault)
+		_, err = f.Write([]byte("hello"))
+		c.Assert(err, NotNil)
+		c.Assert(f.Close(), IsNil)
+		c.Assert(strings.Join(ffs.ops, ","), Equals, "tempfile,write,close,remove")
+	})
+	_, err := os.Stat(name)
+	c.Assert(os.IsNotExist(err), Equals, true)
+}
+
+// txnFiles are the files written by the transaction tests.
+var txnFiles = []string{"object/part.1", "object/xl.json"}
+
+// writeTxn publishes content to all txnFiles in a single transaction.
+func writeTxn(dir, content string) error {
+	txn, err := NewTxn(dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range txnFiles {
+		f, err := txn.Create(name)
+		if err != nil {
+			txn.Abort()
+			return err
+		}
+		if _, err = f.Write([]byte(content)); err != nil {
+			txn.Abort()
+			return err
+		}
+	}
+	return txn.Commit()
+}
+
+// readTxnFiles returns the content of all txnFiles, "" for missing ones.
+func readTxnFiles(c *C, dir string) []string {
+	var contents []string
+	for _, name := range txnFiles {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			c.Assert(os.IsNotExist(err), Equals, true)
+		}
+		contents = append(contents, string(data))
+	}
+	return contents
+}
+
+// leftovers returns the temporary files and intent logs under dir.
+func leftovers(c *C, dir string) []string {
+	var names []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(info.Name(), "$") {
+			names = append(names, path)
+		}
+		return nil
+	})
+	c.Assert(err, IsNil)
+	return names
+}
+
+func (s *MySuite) TestTxn(c *C) {
+	dir := filepath.Join(s.root, "txn")
+	defer os.RemoveAll(dir)
+
+	c.Assert(writeTxn(dir, "v1"), IsNil)
+	c.Assert(readTxnFiles(c, dir), DeepEquals, []string{"v1", "v1"})
+	c.Assert(leftovers(c, dir), HasLen, 0)
+
+	// Aborted transactions leave the published files alone.
+	txn, err := NewTxn(dir)
+	c.Assert(err, IsNil)
+	f, err := txn.Create(txnFiles[0])
+	c.Assert(err, IsNil)
+	_, err = f.Write([]byte("v2"))
+	c.Assert(err, IsNil)
+	c.Assert(f.Close(), IsNil)
+	c.Assert(readTxnFiles(c, dir), DeepEquals, []string{"v1", "v1"})
+	c.Assert(txn.Abort(), IsNil)
+	c.Assert(txn.Commit(), Equals, ErrTxnDone)
+	c.Assert(readTxnFiles(c, dir), DeepEquals, []string{"v1", "v1"})
+	c.Assert(leftovers(c, dir), HasLen, 0)
+
+	// A single aborted file aborts the transaction.
+	txn, err = NewTxn(dir)
+	c.Assert(err, IsNil)
+	f, err = txn.Create(txnFiles[0])
+	c.Assert(err, IsNil)
+	_, err = txn.Create(txnFiles[1])
+	c.Assert(err, IsNil)
+	c.Assert(f.Abort(), IsNil)
+	c.Assert(txn.Commit(), Equals, ErrTxnFileAborted)
+	c.Assert(readTxnFiles(c, dir), DeepEquals, []string{"v1", "v1"})
+	c.Assert(leftovers(c, dir), HasLen, 0)
+
+	txn, err = NewTxn(dir)
+	c.Assert(err, IsNil)
+	for _, name := range []string{"../escape", "/abs", ".", ""} {
+		_, err = txn.Create(name)
+		c.Assert(err, Equals, ErrTxnName, Commentf("%q", name))
+	}
+	c.Assert(txn.Abort(), IsNil)
+}
+
+// Crashes at every single filesystem operation of a transaction must
+// leave either the old or the new files after Recover, never a mix.
+func (s *MySuite) TestTxnCrash(c *C) {
+	dir := filepath.Join(s.root, "txncrash")
+	defer os.RemoveAll(dir)
+
+	// Count the operations of a complete transaction, once the
+	// directories exist.
+	c.Assert(writeTxn(dir, "v0"), IsNil)
+	var total int
+	withFS(nil, func(ffs *faultFS) {
+		c.Assert(writeTxn(dir, "v0"), IsNil)
+		total = len(ffs.ops)
+	})
+
+	for crashAt := 1; crashAt <= total; crashAt++ {
+		c.Assert(writeTxn(dir, "old"), IsNil)
+		var err error
+		withFaultFS(&faultFS{crashAt: crashAt}, func(ffs *faultFS) {
+			err = writeTxn(dir, "new")
+		})
+		c.Assert(err, NotNil)
+		c.Assert(Recover(dir), IsNil)
+		contents := readTxnFiles(c, dir)
+		if contents[0] != "old" || contents[1] != "old" {
+			c.Assert(contents, DeepEquals, []string{"new", "new"}, Commentf("crash at %d", crashAt))
+		}
+		c.Assert(leftovers(c, dir), HasLen, 0, Commentf("crash at %d", crashAt))
+	}
+}
+
+func (s *MySuite) TestTxnDurableOrder(c *C) {
+	dir := filepath.Join(s.root, "txndurable")
+	defer os.RemoveAll(dir)
+
+	// The new directories and the temporary files in them are durable
+	// before the commit record.
+	withFS(nil, func(ffs *faultFS) {
+		c.Assert(writeTxn(dir, "v1"), IsNil)
+		c.Assert(strings.Join(ffs.ops, ","), Equals, "tempfile,write,open,write,write,"+
+			"open,write,sync,close,sync,close,syncdir,syncdir,syncdir,"+
+			"write,sync,syncdir,rename,rename,syncdir,close,remove")
+	})
+	c.Assert(readTxnFiles(c, dir), DeepEquals, []string{"v1", "v1"})
+}
+
+func (s *MySuite) TestTxnMissingTemp(c *C) {
+	dir := filepath.Join(s.root, "txnmissing")
+	defer os.RemoveAll(dir)
+
+	// Commit notices the missing file before the commit record and
+	// rolls back.
+	txn, err := NewTxn(dir)
+	c.Assert(err, IsNil)
+	f, err := txn.Create(txnFiles[0])
+	c.Assert(err, IsNil)
+	_, err = txn.Create(txnFiles[1])
+	c.Assert(err, IsNil)
+	c.Assert(f.Close(), IsNil)
+	c.Assert(os.Remove(f.tmpfile.Name()), IsNil)
+	err = txn.Commit()
+	c.Assert(os.IsNotExist(err), Equals, true)
+	c.Assert(Recover(dir), IsNil)
+	c.Assert(readTxnFiles(c, dir), DeepEquals, []string{"", ""})
+	c.Assert(leftovers(c, dir), HasLen, 0)
+}
+
+// commitCrash returns a transaction over txnFiles which crashed right
+// after its commit record became durable.
+func commitCrash(c *C, dir string) []*File {
+	txn, err := NewTxn(dir)
+	c.Assert(err, IsNil)
+	var files []*File
+	for _, name := range txnFiles {
+		f, err := txn.Create(name)
+		c.Assert(err, IsNil)
+		_, err = f.Write([]byte("new"))
+		c.Assert(err, IsNil)
+		c.Assert(f.Close(), IsNil)
+		files = append(files, f)
+	}
+	c.Assert(txn.appendRecord(txnRecord{Op: "commit"}), IsNil)
+	c.Assert(txn.log.Close(), IsNil)
+	return files
+}
+
+func (s *MySuite) TestTxnRecoverPartial(c *C) {
+	dir := filepath.Join(s.root, "txnpartial")
+	defer os.RemoveAll(dir)
+
+	// Crashed after publishing the first file, the rest is published.
+	files := commitCrash(c, dir)
+	c.Assert(os.Rename(files[0].tmpfile.Name(), files[0].name), IsNil)
+	c.Assert(Recover(dir), IsNil)
+	c.Assert(readTxnFiles(c, dir), DeepEquals, []string{"new", "new"})
+	c.Assert(leftovers(c, dir), HasLen, 0)
+	for _, name := range txnFiles {
+		c.Assert(os.Remove(filepath.Join(dir, name)), IsNil)
+	}
+
+	// A temporary file lost without being published, nothing is.
+	files = commitCrash(c, dir)
+	c.Assert(os.Remove(files[0].tmpfile.Name()), IsNil)
+	c.Assert(Recover(dir), Equals, ErrTxnCorrupt)
+	c.Assert(readTxnFiles(c, dir), DeepEquals, []string{"", ""})
+	c.Assert(leftovers(c, dir), HasLen, 2)
+}
+
+func (s *MySuite) TestSafeOptions(c *C) {
+	dir := filepath.Join(s.root, "options")
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "sub", "file")
+
+	f, err := CreateFileWithOptions(name, Options{Prefix: "tmp-", Mode: 0640, DirMode: 0750})
+	c.Assert(err, IsNil)
+	entries, err := ioutil.ReadDir(filepath.Dir(name))
+	c.Assert(err, IsNil)
+	c.Assert(entries, HasLen, 1)
+	c.Assert(strings.HasPrefix(entries[0].Name(), "tmp-file."), Equals, true)
+	c.Assert(f.Close(), IsNil)
+
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(name)
+		c.Assert(err, IsNil)
+		c.Assert(fi.Mode().Perm(), Equals, os.FileMode(0640))
+		fi, err = os.Stat(filepath.Dir(name))
+		c.Assert(err, IsNil)
+		c.Assert(fi.Mode().Perm()&^0022, Equals, os.FileMode(0750)&^0022)
+	}
+}
+
+func (s *MySuite) TestTxnOptions(c *C) {
+	dir := filepath.Join(s.root, "txnoptions")
+	defer os.RemoveAll(dir)
+
+	txn, err := NewTxnWithOptions(dir, Options{Prefix: "tmp-", Mode: 0640, DirMode: 0750})
+	c.Assert(err, IsNil)
+	f, err := txn.Create("sub/file")
+	c.Assert(err, IsNil)
+	c.Assert(strings.HasPrefix(filepath.Base(f.tmpfile.Name()), "tmp-file."), Equals, true)
+	c.Assert(txn.Commit(), IsNil)
+
+	if runtime.GOOS != "windows" {
+		fi, err := os.Stat(filepath.Join(dir, "sub", "file"))
+		c.Assert(err, IsNil)
+		c.Assert(fi.Mode().Perm(), Equals, os.FileMode(0640))
+		fi, err = os.Stat(filepath.Join(dir, "sub"))
+		c.Assert(err, IsNil)
+		c.Assert(fi.Mode().Perm()&^0022, Equals, os.FileMode(0750)&^0022)
+	}
+}
+
+func (s *MySuite) TestSafeUnnamed(c *C) {
+	dir := filepath.Join(s.root, "unnamed")
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "file")
+
+	for _, abort := range []bool{false, true} {
+		f, err := CreateFileWithOptions(name, Options{Unnamed: true})
+		c.Assert(err, IsNil)
+		_, err = f.Write([]byte("hello"))
+		c.Assert(err, IsNil)
+		if f.anonymous {
+			// Nothing is visible before Close.
+			entries, err := ioutil.ReadDir(dir)
+			c.Assert(err, IsNil)
+			c.Assert(entries, HasLen, 0)
+		}
+		if abort {
+			c.Assert(f.Abort(), IsNil)
+			_, err = os.Stat(name)
+			c.Assert(os.IsNotExist(err), Equals, true)
+			continue
+		}
+		c.Assert(f.Close(), IsNil)
+		data, err := ioutil.ReadFile(name)
+		c.Assert(err, IsNil)
+		c.Assert(string(data), Equals, "hello")
+		if runtime.GOOS != "windows" {
+			fi, err := os.Stat(name)
+			c.Assert(err, IsNil)
+			c.Assert(fi.Mode().Perm(), Equals, DefaultMode)
+		}
+		c.Assert(os.Remove(name), IsNil)
+	}
+	c.Assert(leftovers(c, dir), HasLen, 0)
+}
+
+func (s *MySuite) TestSweep(c *C) {
+	dir := filepath.Join(s.root, "sweep")
+	defer os.RemoveAll(dir)
+	defer func() { timeNow = time.Now }()
+
+	// Leftovers of a crashed CreateFile, a published file and a
+	// transaction which is still running.
+	stale, err := CreateFile(filepath.Join(dir, "a", "stale"))
+	c.Assert(err, IsNil)
+	stale.tmpfile.Close()
+	other, err := CreateFileWithOptions(filepath.Join(dir, "other"), Options{Prefix: "tmp-"})
+	c.Assert(err, IsNil)
+	other.tmpfile.Close()
+	published, err := CreateFile(filepath.Join(dir, "published"))
+	c.Assert(err, IsNil)
+	c.Assert(published.Close(), IsNil)
+	txn, err := NewTxn(dir)
+	c.Assert(err, IsNil)
+	_, err = txn.Create("a/txn")
+	c.Assert(err, IsNil)
+
+	// Nothing is old enough yet.
+	c.Assert(Sweep(dir, time.Hour), IsNil)
+	c.Assert(leftovers(c, dir), HasLen, 3)
+
+	// The transaction crashed, its log is no longer locked.
+	txn.log.Close()
+	timeNow = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	c.Assert(Sweep(dir, time.Hour), IsNil)
+	// The stale transaction is rolled back and its log removed.
+	c.Assert(leftovers(c, dir), HasLen, 0)
+	_, err = os.Stat(filepath.Join(dir, "other"))
+	c.Assert(os.IsNotExist(err), Equals, true)
+	entries, err := ioutil.ReadDir(dir)
+	c.Assert(err, IsNil)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	c.Assert(names, DeepEquals, []string{"a", "published", other.tmpfile.Name()[len(dir)+1:]})
+
+	c.Assert(Sweep(dir, time.Hour, "tmp-"), IsNil)
+	entries, err = ioutil.ReadDir(dir)
+	c.Assert(err, IsNil)
+	c.Assert(entries, HasLen, 2)
+	txn.Abort()
+}
+
+func (s *MySuite) TestSweepLiveTxn(c *C) {
+	dir := filepath.Join(s.root, "sweeplive")
+	defer os.RemoveAll(dir)
+	defer func() { timeNow = time.Now }()
+
+	txn, err := NewTxn(dir)
+	c.Assert(err, IsNil)
+	f, err := txn.Create("object/part.1")
+	c.Assert(err, IsNil)
+	_, err = f.Write([]byte("data"))
+	c.Assert(err, IsNil)
+	c.Assert(f.Close(), IsNil)
+
+	// The temporary file is old but its transaction log is not.
+	old := time.Now().Add(-2 * time.Hour)
+	c.Assert(os.Chtimes(f.tmpfile.Name(), old, old), IsNil)
+	c.Assert(Sweep(dir, time.Hour), IsNil)
+
+	// An old log is kept as long as the transaction holds its lock.
+	if locked, err := isLogLocked(txn.log.Name()); err == nil && locked {
+		c.Assert(os.Chtimes(txn.log.Name(), old, old), IsNil)
+		c.Assert(Sweep(dir, time.Hour), IsNil)
+	}
+	c.Assert(txn.Commit(), IsNil)
+	data, err := ioutil.ReadFile(filepath.Join(dir, "object/part.1"))
+	c.Assert(err, IsNil)
+	c.Assert(string(data), Equals, "data")
+}
+
+func (s *MySuite) TestSafeRandomAccess(c *C) {
+	dir := filepath.Join(s.root, "random")
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "object")
+
+	src := filepath.Join(s.root, "random-src")
+	c.Assert(ioutil.WriteFile(src, []byte("0123456789"), 0600), IsNil)
+	defer os.Remove(src)
+
+	for _, unnamed := range []bool{false, true} {
+		f, err := CreateFileWithOptions(name, Options{Unnamed: unnamed})
+		c.Assert(err, IsNil)
+
+		// Shards filled out of order.
+		_, err = f.WriteAt([]byte("cccc"), 8)
+		c.Assert(err, IsNil)
+		_, err = f.WriteAt([]byte("aaaa"), 0)
+		c.Assert(err, IsNil)
+		off, err := f.Seek(4, io.SeekStart)
+		c.Assert(err, IsNil)
+		c.Assert(off, Equals, int64(4))
+		_, err = f.Write([]byte("bbbb"))
+		c.Assert(err, IsNil)
+		c.Assert(f.Sync(), IsNil)
+
+		// Appended from a file, which may use copy_file_range.
+		_, err = f.Seek(0, io.SeekEnd)
+		c.Assert(err, IsNil)
+		r, err := os.Open(src)
+		c.Assert(err, IsNil)
+		n, err := io.Copy(f, r)
+		r.Close()
+		c.Assert(err, IsNil)
+		c.Assert(n, Equals, int64(10))
+		n, err = f.ReadFrom(bytes.NewReader([]byte("xyz")))
+		c.Assert(err, IsNil)
+		c.Assert(n, Equals, int64(3))
+
+		c.Assert(f.Truncate(24), IsNil)
+		c.Assert(f.Close(), IsNil)
+		data, err := ioutil.ReadFile(name)
+		c.Assert(err, IsNil)
+		c.Assert(string(data), Equals, "aaaabbbbcccc0123456789xy")
+		c.Assert(os.Remove(name), IsNil)
+	}
+}
+
+func (s *MySuite) TestSafeAbortOnError(c *C) {
+	errFault := errors.New("injected fault")
+	name := filepath.Join(s.root, "abortonerror")
+	testCases := []struct {
+		fault string
+		fn    func(*File) error
+	}{
+		{"writeat", func(f *File) error { _, err := f.WriteAt([]byte("a"), 10); return err }},
+		{"readfrom", func(f *File) error { _, err := f.ReadFrom(bytes.NewReader([]byte("a"))); return err }},
+		{"seek", func(f *File) error { _, err := f.Seek(1, io.SeekStart); return err }},
+		{"truncate", func(f *File) error { return f.Truncate(1) }},
+		{"sync", func(f *File) error { return f.Sync() }},
+	}
+	for _, testCase := range testCases {
+		withFS(map[string]error{testCase.fault: errFault}, func(ffs *faultFS) {
+			f, err := CreateFile(name)
+			c.Assert(err, IsNil)
+			c.Assert(testCase.fn(f), Equals, errFault)
+			c.Assert(strings.Join(ffs.ops, ","), Equals, "tempfile,"+testCase.fault+",close,remove")
+			// The file is aborted, everything else fails.
+			c.Assert(testCase.fn(f), ErrorMatches, ".* on aborted file")
+			_, err = f.Write([]byte("a"))
+			c.Assert(err, ErrorMatches, "write on aborted file")
+			c.Assert(f.Close(), IsNil)
+		})
+		_, err := os.Stat(name)
+		c.Assert(os.IsNotExist(err), Equals, true)
+		c.Assert(leftovers(c, s.root), HasLen, 0)
+	}
+
+	// Errors of the source reader abort as well.
+	f, err := CreateFile(name)
+	c.Assert(err, IsNil)
+	_, err = f.ReadFrom(io.MultiReader(bytes.NewReader([]byte("partial")), errReader{}))
+	c.Assert(err, Equals, errRead)
+	c.Assert(f.Close(), IsNil)
+	_, err = os.Stat(name)
+	c.Assert(os.IsNotExist(err), Equals, true)
+}
+
+var errRead = errors.New("read failed")
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errRead }
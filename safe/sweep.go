@@ -0,0 +1,110 @@
+/*
+ * Minio Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safe
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Sweep removes temporary files under root which were not modified for
+// olderThan, they were left behind by crashed processes.  Temporary files
+// are recognized by their prefix, DefaultPrefix if no prefixes are given.
+//
+// Transactions whose intent log is older than olderThan are recovered as
+// by Recover unless the log is locked by an open Txn, temporary files of
+// other transactions are kept.  Where file locks are not supported, and
+// for files created without a Txn, files still being written must be
+// modified more often than olderThan.
+func Sweep(root string, olderThan time.Duration, prefixes ...string) error {
+	if len(prefixes) == 0 {
+		prefixes = []string{DefaultPrefix}
+	}
+	deadline := timeNow().Add(-olderThan)
+
+	// Recover stale transactions first, the temporary files of live
+	// ones must survive the sweep.  Logs removed concurrently were
+	// committed or aborted.
+	live := make(map[string]bool)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.IsDir() || !strings.HasPrefix(info.Name(), txnLogPrefix) {
+			return nil
+		}
+		dir := filepath.Dir(path)
+		if info.ModTime().Before(deadline) {
+			locked, err := isLogLocked(path)
+			if err == nil && !locked {
+				err = recoverTxn(dir, info.Name())
+			}
+			if err != nil || !locked {
+				return ignoreNotExist(err)
+			}
+		}
+		creates, _, err := readTxnLog(path)
+		if err != nil {
+			return ignoreNotExist(err)
+		}
+		for _, r := range creates {
+			live[filepath.Join(dir, r.Tmp)] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Removed by a concurrent sweep or commit.
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() || live[path] || !info.ModTime().Before(deadline) {
+			return nil
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(info.Name(), prefix) {
+				if err = fs.Remove(path); err != nil && !os.IsNotExist(err) {
+					return err
+				}
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+// ignoreNotExist returns err unless it reports a missing file.
+func ignoreNotExist(err error) error {
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// timeNow is replaced by tests.
+var timeNow = time.Now
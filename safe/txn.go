@@ -0,0 +1,438 @@
+/*
+ * Minio Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// txnLogPrefix prefixes the intent log of a transaction in its directory.
+const txnLogPrefix = "$txnlog."
+
+var (
+	// ErrTxnDone - transaction was already committed or aborted.
+	ErrTxnDone = errors.New("transaction already committed or aborted")
+	// ErrTxnName - file name is not relative to the transaction directory.
+	ErrTxnName = errors.New("file name outside of transaction directory")
+	// ErrTxnFileAborted - a file of the transaction was aborted.
+	ErrTxnFileAborted = errors.New("transaction file aborted")
+	// ErrTxnCorrupt - a committed transaction lost temporary files which
+	// were not published, Recover leaves it alone.
+	ErrTxnCorrupt = errors.New("committed transaction lost temporary files")
+)
+
+// txnRecord is one line of the intent log.
+type txnRecord struct {
+	Op   string `json:"op"`             // "create" or "commit"
+	Tmp  string `json:"tmp,omitempty"`  // temporary file, relative to the directory
+	Name string `json:"name,omitempty"` // named file, relative to the directory
+}
+
+// Txn publishes several files atomically: either all of them or none
+// are renamed to their names, even across a crash.
+//
+// Every temporary file created by the transaction is recorded in a write
+// ahead intent log in the transaction directory.  Commit syncs the
+// temporary files and their directories, then appends a commit record and
+// syncs the log before renaming any file.  Recover uses the log to finish
+// committed transactions and to remove the temporary files of the others.
+//
+// The intent log is locked while the transaction is open, where the
+// platform supports file locks, so that Sweep does not roll back a live
+// transaction however long it takes.
+//
+// Txn is not safe for concurrent use.
+type Txn struct {
+	dir   string
+	fs    filesystem
+	opts  Options
+	log   file
+	files []*File
+	dirs  map[string]bool // directories to sync before the commit record
+	done  bool
+}
+
+// NewTxn starts a transaction creating files under dir.
+func NewTxn(dir string) (*Txn, error) {
+	return NewTxnWithOptions(dir, Options{})
+}
+
+// NewTxnWithOptions is like NewTxn, the files of the transaction are
+// created with the Prefix, Mode and DirMode of opts.  NoSync and Unnamed
+// are ignored, the atomicity of a transaction relies on both.
+func NewTxnWithOptions(dir string, opts Options) (*Txn, error) {
+	if opts.Prefix == "" {
+		opts.Prefix = DefaultPrefix
+	}
+	if opts.Mode == 0 {
+		opts.Mode = DefaultMode
+	}
+	if opts.DirMode == 0 {
+		opts.DirMode = DefaultDirMode
+	}
+
+	txn := &Txn{dir: dir, fs: fs, opts: opts, dirs: make(map[string]bool)}
+	if err := txn.mkdirAll(dir); err != nil {
+		return nil, err
+	}
+	log, err := fs.TempFile(dir, txnLogPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if err = lockLog(log); err != nil {
+		log.Close()
+		fs.Remove(log.Name())
+		return nil, err
+	}
+	txn.log = log
+	return txn, nil
+}
+
+// mkdirAll creates dir and its missing parents and remembers the parents
+// of the created directories, their entries must be synced on Commit.
+func (txn *Txn) mkdirAll(dir string) error {
+	var created []string
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(d); err == nil || !os.IsNotExist(err) {
+			break
+		}
+		created = append(created, d)
+		if filepath.Dir(d) == d {
+			break
+		}
+	}
+	if err := txn.fs.MkdirAll(dir, txn.opts.DirMode); err != nil {
+		return err
+	}
+	for _, d := range created {
+		txn.dirs[filepath.Dir(d)] = true
+	}
+	return nil
+}
+
+// appendRecord writes one record to the intent log.
+func (txn *Txn) appendRecord(record txnRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = txn.log.Write(append(data, '\n'))
+	return err
+}
+
+// Create creates the named file, relative to the transaction directory,
+// like CreateFile.  The file is only published by Commit, its Close syncs
+// and closes the temporary file.
+func (txn *Txn) Create(name string) (*File, error) {
+	if txn.done {
+		return nil, ErrTxnDone
+	}
+	name = filepath.Clean(name)
+	if filepath.IsAbs(name) || name == "." || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+		return nil, ErrTxnName
+	}
+	path := filepath.Join(txn.dir, name)
+	dname := filepath.Dir(path)
+	if err := txn.mkdirAll(dname); err != nil {
+		return nil, err
+	}
+
+	// The temporary file is logged before it is created so that Recover
+	// knows about it even if the process dies right after.
+	tmpname, err := tempName(dname, txn.opts.Prefix+filepath.Base(path)+".")
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := filepath.Rel(txn.dir, tmpname)
+	if err != nil {
+		return nil, err
+	}
+	if err = txn.appendRecord(txnRecord{Op: "create", Tmp: tmp, Name: name}); err != nil {
+		return nil, err
+	}
+	tmpfile, err := txn.fs.OpenFile(tmpname, os.O_RDWR|os.O_CREATE|os.O_EXCL, txn.opts.Mode)
+	if err != nil {
+		return nil, err
+	}
+	// The mode given to open is subject to the umask.
+	if err = txn.fs.Chmod(tmpname, txn.opts.Mode); err != nil {
+		tmpfile.Close()
+		txn.fs.Remove(tmpname)
+		return nil, err
+	}
+
+	f := &File{name: path, tmpfile: tmpfile, fs: txn.fs, prefix: txn.opts.Prefix, txn: txn}
+	txn.files = append(txn.files, f)
+	txn.dirs[dname] = true
+	return f, nil
+}
+
+// Commit closes all files which are still open and publishes them.  The
+// directory entries of the temporary files and of the directories created
+// for them are synced before the commit record is written, so that a
+// durable commit record never refers to files lost by a crash.  If Commit
+// fails before the commit record is durable all temporary files are
+// removed.  Once it is durable Commit does not roll back, errors are
+// returned and Recover completes the transaction.
+func (txn *Txn) Commit() error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	txn.done = true
+
+	for _, f := range txn.files {
+		if err := f.Close(); err != nil {
+			txn.rollback()
+			return err
+		}
+		if f.aborted {
+			txn.rollback()
+			return ErrTxnFileAborted
+		}
+		// Once the commit record is durable every temporary file must
+		// be published, none may be missing.
+		if _, err := txn.fs.Stat(f.tmpfile.Name()); err != nil {
+			txn.rollback()
+			return err
+		}
+	}
+
+	err := txn.syncDirs()
+	if err == nil {
+		err = txn.appendRecord(txnRecord{Op: "commit"})
+	}
+	if err == nil {
+		err = txn.log.Sync()
+	}
+	if err == nil {
+		// The log itself must survive a crash before any rename.
+		err = txn.fs.SyncDir(txn.dir)
+	}
+	if err != nil {
+		txn.rollback()
+		return err
+	}
+
+	var renames []txnRecord
+	for _, f := range txn.files {
+		renames = append(renames, txnRecord{Tmp: f.tmpfile.Name(), Name: f.name})
+	}
+	if err = publish(txn.fs, renames); err != nil {
+		txn.log.Close()
+		return err
+	}
+	if err = txn.log.Close(); err != nil {
+		return err
+	}
+	return txn.fs.Remove(txn.log.Name())
+}
+
+// syncDirs syncs the directories holding temporary files or created
+// directories, in sorted order.
+func (txn *Txn) syncDirs() error {
+	var dirs []string
+	for dir := range txn.dirs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		if err := txn.fs.SyncDir(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Abort removes all temporary files of the transaction.
+func (txn *Txn) Abort() error {
+	if txn.done {
+		return ErrTxnDone
+	}
+	txn.done = true
+	return txn.rollback()
+}
+
+// rollback removes the intent log, then all temporary files.  The log
+// may hold a durable commit record, its removal is synced before any
+// temporary file goes so that Recover never finds the commit record with
+// only some of them.  If that fails the temporary files are left to
+// Recover or Sweep.
+func (txn *Txn) rollback() error {
+	for _, f := range txn.files {
+		if !f.aborted && !f.closed {
+			f.tmpfile.Close()
+		}
+	}
+	txn.log.Close()
+	logErr := txn.fs.Remove(txn.log.Name())
+	if logErr == nil {
+		logErr = txn.fs.SyncDir(txn.dir)
+	}
+	err := logErr
+	for _, f := range txn.files {
+		if f.aborted {
+			continue
+		}
+		f.aborted = true
+		if logErr != nil {
+			continue
+		}
+		if rerr := txn.fs.Remove(f.tmpfile.Name()); rerr != nil && !os.IsNotExist(rerr) && err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+// publish renames the temporary files of renames and syncs every
+// directory a file was renamed into.
+func publish(fs filesystem, renames []txnRecord) error {
+	dirs := make(map[string]bool)
+	for _, r := range renames {
+		if err := fs.Rename(r.Tmp, r.Name); err != nil {
+			return err
+		}
+		dirs[filepath.Dir(r.Name)] = true
+	}
+	for dir := range dirs {
+		if err := fs.SyncDir(dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readTxnLog returns the create records of an intent log and whether it
+// holds a commit record.  A trailing partial line is ignored, it was cut
+// short by a crash.
+func readTxnLog(name string) (creates []txnRecord, committed bool, err error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, false, err
+	}
+	if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+		data = data[:i]
+	} else {
+		data = nil
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var record txnRecord
+		if err = json.Unmarshal(line, &record); err != nil {
+			return nil, false, err
+		}
+		switch record.Op {
+		case "create":
+			creates = append(creates, record)
+		case "commit":
+			committed = true
+		}
+	}
+	return creates, committed, nil
+}
+
+// Recover completes or rolls back the transactions left in dir by a
+// crash.  The temporary files of committed transactions are renamed to
+// their names, those of other transactions are removed.  A committed
+// transaction which lost temporary files is not published at all, its
+// log and files are kept and ErrTxnCorrupt is returned.  Recover must run
+// before new transactions are started in dir, typically at startup.
+func Recover(dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), txnLogPrefix) {
+			continue
+		}
+		if err = recoverTxn(dir, entry.Name()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unpublished returns the creates of a committed transaction whose
+// temporary files remain to be published.  Commit publishes in the order
+// of the log and checks that all temporary files exist before the commit
+// record, the missing ones must be a prefix of creates whose files are
+// in place, otherwise ErrTxnCorrupt is returned.
+func unpublished(creates []txnRecord) ([]txnRecord, error) {
+	for i, r := range creates {
+		_, err := fs.Stat(r.Tmp)
+		if err == nil {
+			for _, rest := range creates[i+1:] {
+				if _, err = fs.Stat(rest.Tmp); err != nil {
+					if os.IsNotExist(err) {
+						return nil, ErrTxnCorrupt
+					}
+					return nil, err
+				}
+			}
+			return creates[i:], nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		if _, err = fs.Stat(r.Name); err != nil {
+			if os.IsNotExist(err) {
+				return nil, ErrTxnCorrupt
+			}
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// recoverTxn completes or rolls back the transaction of the intent log
+// named logname in dir.
+func recoverTxn(dir, logname string) error {
+	log := filepath.Join(dir, logname)
+	creates, committed, err := readTxnLog(log)
+	if err != nil {
+		return err
+	}
+	for i := range creates {
+		creates[i].Tmp = filepath.Join(dir, creates[i].Tmp)
+		creates[i].Name = filepath.Join(dir, creates[i].Name)
+	}
+	if committed {
+		if creates, err = unpublished(creates); err != nil {
+			return err
+		}
+		if err = publish(fs, creates); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range creates {
+			if err = fs.Remove(r.Tmp); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return fs.Remove(log)
+}
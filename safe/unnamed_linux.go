@@ -0,0 +1,76 @@
+//go:build linux
+// +build linux
+
+/*
+ * Minio Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package safe
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// oTmpfile is O_TMPFILE, __O_TMPFILE is the same on all supported
+	// architectures, O_DIRECTORY is not.
+	oTmpfile = 0x400000 | syscall.O_DIRECTORY
+
+	atSymlinkFollow = 0x400
+)
+
+// atFdCwd is AT_FDCWD, a variable as negative constants do not convert
+// to uintptr.
+var atFdCwd = -0x64
+
+// openUnnamed opens an unnamed file in dir with O_TMPFILE.
+func openUnnamed(dir string, perm os.FileMode) (*os.File, error) {
+	f, err := os.OpenFile(dir, os.O_RDWR|oTmpfile, perm)
+	if err != nil {
+		if perr, ok := err.(*os.PathError); ok {
+			switch perr.Err {
+			// EISDIR is returned by kernels older than 3.11 which
+			// ignore __O_TMPFILE and see O_DIRECTORY only.
+			case syscall.EISDIR, syscall.EOPNOTSUPP, syscall.EINVAL:
+				return nil, errUnnamedUnsupported
+			}
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+// linkUnnamed links the unnamed file open as fd to newname.  Linking the
+// descriptor itself needs CAP_DAC_READ_SEARCH, the /proc link does not.
+func linkUnnamed(fd uintptr, newname string) error {
+	oldname := "/proc/self/fd/" + strconv.FormatUint(uint64(fd), 10)
+	oldp, err := syscall.BytePtrFromString(oldname)
+	if err != nil {
+		return err
+	}
+	newp, err := syscall.BytePtrFromString(newname)
+	if err != nil {
+		return err
+	}
+	_, _, errno := syscall.Syscall6(syscall.SYS_LINKAT, uintptr(atFdCwd), uintptr(unsafe.Pointer(oldp)),
+		uintptr(atFdCwd), uintptr(unsafe.Pointer(newp)), atSymlinkFollow, 0)
+	if errno != 0 {
+		return &os.LinkError{Op: "linkat", Old: oldname, New: newname, Err: errno}
+	}
+	return nil
+}
@@ -1,7 +1,7 @@
-// +build linux,amd64
+// +build !linux
 
 /*
- * Minio Cloud Storage, (C) 2014-2016 Minio, Inc.
+ * Minio Cloud Storage (C) 2016 Minio, Inc.
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -16,6 +16,16 @@
  * limitations under the License.
  */
 
-package sha1
+package safe
 
-//go:generate yasm -f elf64 sha1_sse3_amd64.asm -o sha1_sse3_amd64.syso
+import "os"
+
+// openUnnamed - O_TMPFILE is linux only.
+func openUnnamed(dir string, perm os.FileMode) (*os.File, error) {
+	return nil, errUnnamedUnsupported
+}
+
+// linkUnnamed is never called, openUnnamed always fails.
+func linkUnnamed(fd uintptr, newname string) error {
+	return errUnnamedUnsupported
+}
@@ -0,0 +1,232 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Paths relative to the root of the filesystem, see ReadDisks.
+const (
+	sysFSBLOCKDEVICES = "/sys/block"
+	sysFSCLASSNVME    = "/sys/class/nvme"
+)
+
+// Transports of disks.
+const (
+	TransportSCSI   = "scsi"
+	TransportNVMe   = "nvme"
+	TransportVirtio = "virtio"
+	TransportOther  = "other"
+)
+
+// Health counters of a disk, as far as the kernel exposes them in sysfs.
+// Counters which are not available are zero.
+type Health struct {
+	State string // device state, like "running" or "offline" for SCSI, "live" for NVMe
+
+	// SCSI midlayer counters since boot.
+	IORequests uint64 // commands issued
+	IODone     uint64 // commands completed
+	IOErrors   uint64 // commands completed with an error
+
+	// Temperature in degrees Celsius from hwmon (drivetemp for SATA,
+	// nvme for NVMe), zero if unknown.
+	Temperature         float64
+	TemperatureCritical float64
+}
+
+// Disk describes a block device backed by a disk.
+type Disk struct {
+	Name      string // kernel name, like sda or nvme0n1
+	Device    string // device node, like /dev/sda
+	Transport string // one of the Transport* constants
+
+	Vendor   string
+	Model    string
+	Serial   string
+	WWN      string
+	Firmware string
+
+	Size              uint64 // bytes
+	LogicalBlockSize  uint64
+	PhysicalBlockSize uint64
+	Rotational        bool
+	ReadOnly          bool
+	Removable         bool
+	QueueDepth        int    // device queue depth, nr_requests of the block layer for NVMe
+	Scheduler         string // active I/O scheduler
+
+	Health Health
+}
+
+// readAttr returns the trimmed content of a sysfs attribute, empty if it
+// does not exist.
+func readAttr(path ...string) string {
+	value, err := ioutil.ReadFile(filepath.Join(path...))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(value))
+}
+
+// readUint parses a decimal or 0x prefixed hexadecimal attribute.
+func readUint(path ...string) uint64 {
+	n, _ := strconv.ParseUint(readAttr(path...), 0, 64)
+	return n
+}
+
+// readBool parses a 0 or 1 attribute.
+func readBool(path ...string) bool {
+	return readAttr(path...) == "1"
+}
+
+// activeScheduler returns the scheduler in brackets, like mq-deadline
+// from "none [mq-deadline] kyber".
+func activeScheduler(schedulers string) string {
+	i := strings.IndexByte(schedulers, '[')
+	j := strings.IndexByte(schedulers, ']')
+	if i < 0 || j < i {
+		return schedulers
+	}
+	return schedulers[i+1 : j]
+}
+
+// vpdSerial extracts the unit serial number from the binary VPD page 0x80.
+func vpdSerial(page []byte) string {
+	if len(page) < 4 {
+		return ""
+	}
+	n := int(page[2])<<8 | int(page[3])
+	if n > len(page)-4 {
+		n = len(page) - 4
+	}
+	return string(bytes.TrimSpace(bytes.Trim(page[4:4+n], "\x00")))
+}
+
+// readTemperature reads temp1_input and temp1_crit, in millidegrees, of
+// the first hwmon device under dir.
+func readTemperature(dir string, health *Health) {
+	hwmons, _ := filepath.Glob(filepath.Join(dir, "hwmon", "hwmon*"))
+	if len(hwmons) == 0 {
+		// NVMe controllers have their hwmon device right below them.
+		hwmons, _ = filepath.Glob(filepath.Join(dir, "hwmon*"))
+	}
+	if len(hwmons) == 0 {
+		return
+	}
+	if milli, err := strconv.ParseInt(readAttr(hwmons[0], "temp1_input"), 10, 64); err == nil {
+		health.Temperature = float64(milli) / 1000
+	}
+	if milli, err := strconv.ParseInt(readAttr(hwmons[0], "temp1_crit"), 10, 64); err == nil {
+		health.TemperatureCritical = float64(milli) / 1000
+	}
+}
+
+// nvmeController returns the controller of an NVMe namespace, nvme0 for
+// nvme0n1.
+func nvmeController(name string) string {
+	i := len("nvme")
+	for i < len(name) && name[i] >= '0' && name[i] <= '9' {
+		i++
+	}
+	return name[:i]
+}
+
+// readDisk reads the disk name from sysfs under root.
+func readDisk(root, name string) Disk {
+	block := filepath.Join(root, sysFSBLOCKDEVICES, name)
+	device := filepath.Join(block, "device")
+
+	disk := Disk{
+		Name:              name,
+		Device:            filepath.Join(devROOT, name),
+		Size:              readUint(block, "size") * 512, // always in 512 byte sectors
+		LogicalBlockSize:  readUint(block, "queue", "logical_block_size"),
+		PhysicalBlockSize: readUint(block, "queue", "physical_block_size"),
+		Rotational:        readBool(block, "queue", "rotational"),
+		ReadOnly:          readBool(block, "ro"),
+		Removable:         readBool(block, "removable"),
+		Scheduler:         activeScheduler(readAttr(block, "queue", "scheduler")),
+	}
+
+	switch {
+	case strings.HasPrefix(name, "nvme"):
+		disk.Transport = TransportNVMe
+		ctrl := filepath.Join(root, sysFSCLASSNVME, nvmeController(name))
+		disk.Model = readAttr(ctrl, "model")
+		disk.Serial = readAttr(ctrl, "serial")
+		disk.Firmware = readAttr(ctrl, "firmware_rev")
+		disk.WWN = readAttr(block, "wwid")
+		disk.QueueDepth = int(readUint(block, "queue", "nr_requests"))
+		disk.Health.State = readAttr(ctrl, "state")
+		readTemperature(ctrl, &disk.Health)
+	case readAttr(device, "scsi_level") != "":
+		disk.Transport = TransportSCSI
+		disk.Vendor = readAttr(device, "vendor")
+		disk.Model = readAttr(device, "model")
+		disk.Firmware = readAttr(device, "rev")
+		disk.WWN = readAttr(device, "wwid")
+		if page, err := ioutil.ReadFile(filepath.Join(device, "vpd_pg80")); err == nil {
+			disk.Serial = vpdSerial(page)
+		}
+		disk.QueueDepth = int(readUint(device, "queue_depth"))
+		disk.Health.State = readAttr(device, "state")
+		disk.Health.IORequests = readUint(device, "iorequest_cnt")
+		disk.Health.IODone = readUint(device, "iodone_cnt")
+		disk.Health.IOErrors = readUint(device, "ioerr_cnt")
+		readTemperature(device, &disk.Health)
+	case strings.HasPrefix(name, "vd"):
+		disk.Transport = TransportVirtio
+		disk.Serial = readAttr(block, "serial")
+		disk.QueueDepth = int(readUint(block, "queue", "nr_requests"))
+	default:
+		disk.Transport = TransportOther
+		disk.Model = readAttr(device, "model")
+		disk.QueueDepth = int(readUint(block, "queue", "nr_requests"))
+	}
+	return disk
+}
+
+// ReadDisks reads the disks from sysfs under root, "/" except in tests.
+// Block devices which are not backed by a device, like loop, ram, md and
+// device mapper devices, are skipped.
+func ReadDisks(root string) (Disks, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(root, sysFSBLOCKDEVICES))
+	if err != nil {
+		if os.IsNotExist(err) {
+			// may be a container without sysfs, ignore this
+			return Disks{}, nil
+		}
+		return nil, err
+	}
+	d := Disks{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if _, err := os.Stat(filepath.Join(root, sysFSBLOCKDEVICES, name, "device")); err != nil {
+			continue
+		}
+		disk := readDisk(root, name)
+		d[disk.Device] = disk
+	}
+	return d, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	. "github.com/minio/check"
+)
+
+func (s *MySuite) TestReadDisks(c *C) {
+	disks, err := ReadDisks("testdata/sysfs")
+	c.Assert(err, IsNil)
+	// loop0 is not backed by a device.
+	c.Assert(disks.Len(), Equals, 3)
+
+	c.Assert(disks.Get("/dev/sda"), DeepEquals, Disk{
+		Name:              "sda",
+		Device:            "/dev/sda",
+		Transport:         TransportSCSI,
+		Vendor:            "ATA",
+		Model:             "ST4000NM0035-1V4",
+		Serial:            "ZC1ABCDE",
+		WWN:               "naa.5000c500a1b2c3d4",
+		Firmware:          "TN03",
+		Size:              7814037168 * 512,
+		LogicalBlockSize:  512,
+		PhysicalBlockSize: 4096,
+		Rotational:        true,
+		QueueDepth:        32,
+		Scheduler:         "mq-deadline",
+		Health: Health{
+			State:               "running",
+			IORequests:          8000,
+			IODone:              7998,
+			IOErrors:            2,
+			Temperature:         38,
+			TemperatureCritical: 70,
+		},
+	})
+
+	c.Assert(disks.Get("/dev/nvme0n1"), DeepEquals, Disk{
+		Name:              "nvme0n1",
+		Device:            "/dev/nvme0n1",
+		Transport:         TransportNVMe,
+		Model:             "SAMSUNG MZQLB1T9HAJR-00007",
+		Serial:            "S439NA0M123456",
+		WWN:               "eui.0025388b91b2c3d4",
+		Firmware:          "EDA5202Q",
+		Size:              3750748848 * 512,
+		LogicalBlockSize:  512,
+		PhysicalBlockSize: 512,
+		QueueDepth:        1023,
+		Scheduler:         "none",
+		Health: Health{
+			State:               "live",
+			Temperature:         41.85,
+			TemperatureCritical: 84.85,
+		},
+	})
+
+	vda := disks.Get("/dev/vda")
+	c.Assert(vda.Transport, Equals, TransportVirtio)
+	c.Assert(vda.Serial, Equals, "vol-0123")
+	c.Assert(vda.ReadOnly, Equals, true)
+	c.Assert(vda.QueueDepth, Equals, 256)
+
+	// No sysfs at all, like in some containers.
+	disks, err = ReadDisks("testdata/nonexistent")
+	c.Assert(err, IsNil)
+	c.Assert(disks.Len(), Equals, 0)
+}
+
+func (s *MySuite) TestSysfsParsers(c *C) {
+	c.Assert(activeScheduler("none [mq-deadline] kyber"), Equals, "mq-deadline")
+	c.Assert(activeScheduler("none"), Equals, "none")
+	c.Assert(vpdSerial([]byte{0, 0x80, 0, 4, 'A', 'B', 'C', 0}), Equals, "ABC")
+	c.Assert(vpdSerial([]byte{0, 0x80, 0, 9, 'A'}), Equals, "A")
+	c.Assert(vpdSerial(nil), Equals, "")
+	c.Assert(nvmeController("nvme10n2"), Equals, "nvme10")
+}
@@ -0,0 +1,282 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Paths relative to the root of the filesystem, see ReadDiskStats.
+const procDISKSTATS = "/proc/diskstats"
+
+// sectorSize is the unit of the sector counters, independent of the
+// block size of the device.
+const sectorSize = 512
+
+// IOCounters are the cumulative I/O counters of a block device, see the
+// kernel's Documentation/block/stat.rst.
+type IOCounters struct {
+	ReadIOs      uint64 // reads completed
+	ReadMerges   uint64
+	ReadSectors  uint64
+	ReadTicks    uint64 // milliseconds spent reading
+	WriteIOs     uint64 // writes completed
+	WriteMerges  uint64
+	WriteSectors uint64
+	WriteTicks   uint64 // milliseconds spent writing
+	InFlight     uint64 // I/Os currently in flight, not cumulative
+	IOTicks      uint64 // milliseconds the device was busy
+	TimeInQueue  uint64 // weighted milliseconds of all I/Os
+}
+
+// parseIOCounters parses the counter fields of a stat line, at least the
+// first 11 fields must be present.
+func parseIOCounters(fields []string) (IOCounters, bool) {
+	if len(fields) < 11 {
+		return IOCounters{}, false
+	}
+	var n [11]uint64
+	for i := range n {
+		v, err := strconv.ParseUint(fields[i], 10, 64)
+		if err != nil {
+			return IOCounters{}, false
+		}
+		n[i] = v
+	}
+	return IOCounters{
+		ReadIOs: n[0], ReadMerges: n[1], ReadSectors: n[2], ReadTicks: n[3],
+		WriteIOs: n[4], WriteMerges: n[5], WriteSectors: n[6], WriteTicks: n[7],
+		InFlight: n[8], IOTicks: n[9], TimeInQueue: n[10],
+	}, true
+}
+
+// ReadDiskStats reads the counters of all block devices, keyed by kernel
+// name, from /proc/diskstats under root.  If it is not available the
+// counters are read from /sys/block/*/stat.  root is "/" except in tests.
+func ReadDiskStats(root string) (map[string]IOCounters, error) {
+	f, err := os.Open(filepath.Join(root, procDISKSTATS))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return readSysBlockStats(root)
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	counters := make(map[string]IOCounters)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// major minor name counters...
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		if c, ok := parseIOCounters(fields[3:]); ok {
+			counters[fields[2]] = c
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	return counters, nil
+}
+
+// readSysBlockStats reads the counters from /sys/block/*/stat under root.
+func readSysBlockStats(root string) (map[string]IOCounters, error) {
+	entries, err := ioutil.ReadDir(filepath.Join(root, sysFSBLOCKDEVICES))
+	if err != nil {
+		return nil, err
+	}
+	counters := make(map[string]IOCounters)
+	for _, entry := range entries {
+		if c, ok := parseIOCounters(strings.Fields(readAttr(root, sysFSBLOCKDEVICES, entry.Name(), "stat"))); ok {
+			counters[entry.Name()] = c
+		}
+	}
+	return counters, nil
+}
+
+// DiskStats are the I/O statistics of a block device over a sampling
+// interval.
+type DiskStats struct {
+	Name             string
+	ReadIOPS         float64
+	WriteIOPS        float64
+	ReadBytesPerSec  float64
+	WriteBytesPerSec float64
+	ReadAwait        time.Duration // average time of a completed read, queueing included
+	WriteAwait       time.Duration
+	Await            time.Duration // average time of a completed read or write
+	Util             float64       // fraction of the interval the device was busy, 0 to 1
+	InFlight         uint64        // I/Os in flight at the end of the interval
+}
+
+// delta returns cur-prev of a cumulative counter, zero if the counter
+// went backwards because the device was re-created.
+func delta(cur, prev uint64) uint64 {
+	if cur < prev {
+		return 0
+	}
+	return cur - prev
+}
+
+// await returns the average time of ios I/Os which took ticks
+// milliseconds in total.
+func await(ticks, ios uint64) time.Duration {
+	if ios == 0 {
+		return 0
+	}
+	return time.Duration(ticks) * time.Millisecond / time.Duration(ios)
+}
+
+// computeDiskStats computes the statistics of a device between two
+// samples taken elapsed apart.
+func computeDiskStats(name string, prev, cur IOCounters, elapsed time.Duration) DiskStats {
+	secs := elapsed.Seconds()
+	reads := delta(cur.ReadIOs, prev.ReadIOs)
+	writes := delta(cur.WriteIOs, prev.WriteIOs)
+	readTicks := delta(cur.ReadTicks, prev.ReadTicks)
+	writeTicks := delta(cur.WriteTicks, prev.WriteTicks)
+
+	util := float64(delta(cur.IOTicks, prev.IOTicks)) / (secs * 1000)
+	if util > 1 {
+		util = 1
+	}
+	return DiskStats{
+		Name:             name,
+		ReadIOPS:         float64(reads) / secs,
+		WriteIOPS:        float64(writes) / secs,
+		ReadBytesPerSec:  float64(delta(cur.ReadSectors, prev.ReadSectors)*sectorSize) / secs,
+		WriteBytesPerSec: float64(delta(cur.WriteSectors, prev.WriteSectors)*sectorSize) / secs,
+		ReadAwait:        await(readTicks, reads),
+		WriteAwait:       await(writeTicks, writes),
+		Await:            await(readTicks+writeTicks, reads+writes),
+		Util:             util,
+		InFlight:         cur.InFlight,
+	}
+}
+
+// Sampler computes the I/O statistics of all block devices from
+// consecutive readings of their counters.
+type Sampler struct {
+	root string
+	now  func() time.Time // replaced by tests
+
+	lock     sync.Mutex
+	prev     map[string]IOCounters
+	prevTime time.Time
+	stats    map[string]DiskStats
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewSampler returns a sampler reading the counters under root, "/"
+// except in tests.
+func NewSampler(root string) *Sampler {
+	return &Sampler{root: root, now: time.Now, stats: make(map[string]DiskStats)}
+}
+
+// Sample reads the counters and returns the statistics since the
+// previous Sample, keyed by kernel name.  The first Sample returns no
+// statistics.
+func (s *Sampler) Sample() (map[string]DiskStats, error) {
+	counters, err := ReadDiskStats(s.root)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := make(map[string]DiskStats)
+	if elapsed := now.Sub(s.prevTime); s.prev != nil && elapsed > 0 {
+		for name, cur := range counters {
+			prev, ok := s.prev[name]
+			if !ok {
+				// New device, the next sample has its statistics.
+				continue
+			}
+			stats[name] = computeDiskStats(name, prev, cur, elapsed)
+		}
+	}
+	s.prev = counters
+	s.prevTime = now
+	s.stats = stats
+	return stats, nil
+}
+
+// Stats returns the statistics of the last Sample.
+func (s *Sampler) Stats() map[string]DiskStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stats := make(map[string]DiskStats, len(s.stats))
+	for name, st := range s.stats {
+		stats[name] = st
+	}
+	return stats
+}
+
+// Start samples every interval in the background until Stop, the
+// statistics are available from Stats.  Errors reading the counters are
+// ignored, the next sample tries again.
+func (s *Sampler) Start(interval time.Duration) {
+	s.lock.Lock()
+	if s.stop != nil {
+		s.lock.Unlock()
+		return
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	stop, done := s.stop, s.done
+	s.lock.Unlock()
+
+	s.Sample()
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.Sample()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the sampling started by Start.
+func (s *Sampler) Stop() {
+	s.lock.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.lock.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
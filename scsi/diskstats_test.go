@@ -0,0 +1,107 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/minio/check"
+)
+
+// setDiskStats installs a fixture as /proc/diskstats under root.
+func setDiskStats(c *C, root, fixture string) {
+	data, err := ioutil.ReadFile(filepath.Join("testdata/diskstats", fixture))
+	c.Assert(err, IsNil)
+	c.Assert(os.MkdirAll(filepath.Join(root, "proc"), 0700), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(root, procDISKSTATS), data, 0600), IsNil)
+}
+
+func (s *MySuite) TestSampler(c *C) {
+	root, err := ioutil.TempDir("", "scsi-")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(root)
+
+	now := time.Unix(1000, 0)
+	sampler := NewSampler(root)
+	sampler.now = func() time.Time { return now }
+
+	setDiskStats(c, root, "diskstats.0")
+	stats, err := sampler.Sample()
+	c.Assert(err, IsNil)
+	c.Assert(stats, HasLen, 0)
+
+	setDiskStats(c, root, "diskstats.1")
+	now = now.Add(2 * time.Second)
+	stats, err = sampler.Sample()
+	c.Assert(err, IsNil)
+
+	c.Assert(stats["sda"], DeepEquals, DiskStats{
+		Name:             "sda",
+		ReadIOPS:         100,
+		WriteIOPS:        50,
+		ReadBytesPerSec:  1024 * 1024,
+		WriteBytesPerSec: 512 * 1024,
+		ReadAwait:        5 * time.Millisecond,
+		WriteAwait:       20 * time.Millisecond,
+		Await:            10 * time.Millisecond,
+		Util:             0.25,
+		InFlight:         4,
+	})
+	// Counters of a re-created device went backwards.
+	c.Assert(stats["sdb"], DeepEquals, DiskStats{Name: "sdb"})
+	// A new device has statistics from the next sample on, malformed
+	// lines are skipped.
+	_, ok := stats["nvme0n1"]
+	c.Assert(ok, Equals, false)
+	_, ok = stats["sda1"]
+	c.Assert(ok, Equals, false)
+	c.Assert(stats["loop0"], DeepEquals, DiskStats{Name: "loop0"})
+
+	c.Assert(sampler.Stats(), DeepEquals, stats)
+}
+
+func (s *MySuite) TestSamplerStart(c *C) {
+	root, err := ioutil.TempDir("", "scsi-")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(root)
+	setDiskStats(c, root, "diskstats.0")
+
+	sampler := NewSampler(root)
+	sampler.Start(time.Millisecond)
+	for len(sampler.Stats()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	sampler.Stop()
+	sampler.Stop()
+	c.Assert(sampler.Stats()["sda"].Name, Equals, "sda")
+}
+
+func (s *MySuite) TestReadDiskStatsSysfs(c *C) {
+	// No /proc/diskstats, the counters come from /sys/block/*/stat.
+	counters, err := ReadDiskStats("testdata/sysfs")
+	c.Assert(err, IsNil)
+	c.Assert(counters, HasLen, 2)
+	c.Assert(counters["sda"], DeepEquals, IOCounters{
+		ReadIOs: 111815, ReadMerges: 37330, ReadSectors: 2513962, ReadTicks: 12595,
+		WriteIOs: 33259, WriteMerges: 47041, WriteSectors: 1953448, WriteTicks: 18504,
+		InFlight: 2, IOTicks: 8272, TimeInQueue: 32156,
+	})
+	c.Assert(counters["loop0"], DeepEquals, IOCounters{})
+}
@@ -0,0 +1,301 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType is the kind of a hotplug Event.
+type EventType int
+
+// Hotplug event types.
+const (
+	// Added - a block device appeared, or a filesystem was mounted
+	Added EventType = iota + 1
+	// Removed - a block device disappeared, or a filesystem was unmounted
+	Removed
+	// Remounted - a mount point now has a different filesystem or options
+	Remounted
+	// ReadOnly - a mount point went read-only, like after an I/O error
+	// with errors=remount-ro
+	ReadOnly
+)
+
+var eventTypeNames = map[EventType]string{
+	Added:     "Added",
+	Removed:   "Removed",
+	Remounted: "Remounted",
+	ReadOnly:  "ReadOnly",
+}
+
+func (t EventType) String() string {
+	if name, ok := eventTypeNames[t]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// Event is a change of the block devices or of the mount table.  Events
+// of block devices have Device set, events of mount points Dir and Mount,
+// the latter being the last known mount for Removed.
+type Event struct {
+	Type   EventType
+	Device string // device node, like /dev/sdb
+	Dir    string // mount point
+	Mount  Mountinfo
+	Time   time.Time
+}
+
+// clock is the time source of the Watcher, replaced by tests.
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// eventQueueSize is the buffer of the events channel, polling blocks
+// while it is full so that no event is lost.
+const eventQueueSize = 64
+
+// Watcher polls the mount table and the block devices and turns their
+// changes into events.  /proc/self/mountinfo can not be watched with
+// inotify, and polling it is cheap.
+type Watcher struct {
+	root     string
+	interval time.Duration
+	clock    clock
+	events   chan Event
+
+	lock   sync.Mutex
+	mounts map[string]Mountinfo // keyed by mount point
+	disks  map[string]bool      // keyed by device node
+	stop   chan struct{}
+	done   chan struct{}
+	closed bool
+}
+
+// NewWatcher returns a watcher polling every interval, the state under
+// root at this time is the baseline for the first events.  root is "/"
+// except in tests.
+func NewWatcher(root string, interval time.Duration) (*Watcher, error) {
+	w := &Watcher{
+		root:     root,
+		interval: interval,
+		clock:    realClock{},
+		events:   make(chan Event, eventQueueSize),
+	}
+	var err error
+	if w.mounts, err = w.readMounts(); err != nil {
+		return nil, err
+	}
+	if w.disks, err = w.readDisks(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Events returns the channel the events are delivered on, it is closed
+// by Stop.
+func (w *Watcher) Events() <-chan Event {
+	return w.events
+}
+
+func (w *Watcher) readMounts() (map[string]Mountinfo, error) {
+	list, err := ReadMountInfo(w.root)
+	if err != nil {
+		return nil, err
+	}
+	mounts := make(map[string]Mountinfo)
+	for _, mount := range list {
+		// The last mount over a directory is the visible one.
+		mounts[mount.Dir] = mount
+	}
+	return mounts, nil
+}
+
+func (w *Watcher) readDisks() (map[string]bool, error) {
+	disks := make(map[string]bool)
+	entries, err := ioutil.ReadDir(filepath.Join(w.root, sysFSBLOCKDEVICES))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return disks, nil
+		}
+		return nil, err
+	}
+	for _, entry := range entries {
+		disks[filepath.Join(devROOT, entry.Name())] = true
+	}
+	return disks, nil
+}
+
+// isReadOnly reports whether either the mount or its superblock is
+// read-only.
+func isReadOnly(m Mountinfo) bool {
+	for _, opts := range []string{m.Opts, m.SuperOpts} {
+		for _, opt := range strings.Split(opts, ",") {
+			if opt == "ro" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// poll reads the current state and returns the events since the last
+// poll, block device events first, each kind in the order of its key.
+// On error the state is left unchanged.
+func (w *Watcher) poll() ([]Event, error) {
+	mounts, err := w.readMounts()
+	if err != nil {
+		return nil, err
+	}
+	disks, err := w.readDisks()
+	if err != nil {
+		return nil, err
+	}
+	now := w.clock.Now()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	var events []Event
+	for _, dev := range sortedKeys(disks, w.disks) {
+		switch {
+		case disks[dev] && !w.disks[dev]:
+			events = append(events, Event{Type: Added, Device: dev, Time: now})
+		case !disks[dev] && w.disks[dev]:
+			events = append(events, Event{Type: Removed, Device: dev, Time: now})
+		}
+	}
+
+	dirs := make(map[string]bool)
+	for dir := range mounts {
+		dirs[dir] = true
+	}
+	for dir := range w.mounts {
+		dirs[dir] = true
+	}
+	var sorted []string
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	sort.Strings(sorted)
+	for _, dir := range sorted {
+		cur, isMounted := mounts[dir]
+		prev, wasMounted := w.mounts[dir]
+		event := Event{Device: cur.Device, Dir: dir, Mount: cur, Time: now}
+		switch {
+		case isMounted && !wasMounted:
+			event.Type = Added
+		case !isMounted && wasMounted:
+			event.Type = Removed
+			event.Device, event.Mount = prev.Device, prev
+		case isReadOnly(cur) && !isReadOnly(prev):
+			event.Type = ReadOnly
+		case cur.ID != prev.ID || cur.Major != prev.Major || cur.Minor != prev.Minor ||
+			cur.Opts != prev.Opts || cur.SuperOpts != prev.SuperOpts:
+			event.Type = Remounted
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+
+	w.mounts = mounts
+	w.disks = disks
+	return events, nil
+}
+
+// sortedKeys returns the keys of both sets, sorted.
+func sortedKeys(a, b map[string]bool) []string {
+	var keys []string
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if !a[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Start polls in the background until Stop.  Errors reading the state
+// are ignored, the next poll tries again.
+func (w *Watcher) Start() {
+	w.lock.Lock()
+	if w.stop != nil || w.closed {
+		w.lock.Unlock()
+		return
+	}
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	stop, done := w.stop, w.done
+	w.lock.Unlock()
+
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-w.clock.After(w.interval):
+			case <-stop:
+				return
+			}
+			events, err := w.poll()
+			if err != nil {
+				continue
+			}
+			for _, event := range events {
+				select {
+				case w.events <- event:
+				case <-stop:
+					return
+				}
+			}
+		}
+	}()
+}
+
+// Stop ends the polling started by Start and closes the events channel,
+// the watcher can not be restarted.
+func (w *Watcher) Stop() {
+	w.lock.Lock()
+	stop, done := w.stop, w.done
+	w.stop, w.done = nil, nil
+	closed := w.closed
+	w.closed = true
+	w.lock.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	if !closed {
+		close(w.events)
+	}
+}
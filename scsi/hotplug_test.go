@@ -0,0 +1,240 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/minio/check"
+)
+
+// manualClock fires the channels returned by After when Advance moves
+// past their deadline.
+type manualClock struct {
+	lock    sync.Mutex
+	now     time.Time
+	waiters []clockWaiter
+}
+
+type clockWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func (m *manualClock) Now() time.Time {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.now
+}
+
+func (m *manualClock) After(d time.Duration) <-chan time.Time {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	ch := make(chan time.Time, 1)
+	m.waiters = append(m.waiters, clockWaiter{m.now.Add(d), ch})
+	return ch
+}
+
+func (m *manualClock) Advance(d time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.now = m.now.Add(d)
+	var pending []clockWaiter
+	for _, w := range m.waiters {
+		if w.deadline.After(m.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- m.now
+	}
+	m.waiters = pending
+}
+
+// waitWaiters waits until n channels are waiting on the clock.
+func (m *manualClock) waitWaiters(c *C, n int) {
+	for i := 0; i < 1000; i++ {
+		m.lock.Lock()
+		waiting := len(m.waiters)
+		m.lock.Unlock()
+		if waiting == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	c.Fatalf("clock has no %d waiters", n)
+}
+
+// fakeRoot is a filesystem root with a mount table and block devices.
+type fakeRoot string
+
+const (
+	mountRoot  = "22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw,errors=remount-ro\n"
+	mountDisk1 = "30 22 8:16 / /mnt/disk1 rw,noatime shared:20 - xfs /dev/sdb rw,attr2\n"
+	mountDisk2 = "31 22 8:32 / /mnt/disk2 rw,noatime shared:21 - xfs /dev/sdc rw,attr2\n"
+)
+
+func newFakeRoot(c *C) fakeRoot {
+	dir, err := ioutil.TempDir("", "hotplug-")
+	c.Assert(err, IsNil)
+	root := fakeRoot(dir)
+	root.setMounts(c, mountRoot+mountDisk1)
+	root.addDisk(c, "sda", "8:1", "sda1")
+	root.addDisk(c, "sdb", "8:16", "sdb")
+	return root
+}
+
+func (r fakeRoot) setMounts(c *C, mountinfo string) {
+	path := filepath.Join(string(r), procSELFMOUNTINFO)
+	c.Assert(os.MkdirAll(filepath.Dir(path), 0755), IsNil)
+	c.Assert(ioutil.WriteFile(path, []byte(mountinfo), 0644), IsNil)
+}
+
+func (r fakeRoot) addDisk(c *C, name, dev, devname string) {
+	c.Assert(os.MkdirAll(filepath.Join(string(r), sysFSBLOCKDEVICES, name), 0755), IsNil)
+	uevent := filepath.Join(string(r), sysFSDEVBLOCK, dev, "uevent")
+	c.Assert(os.MkdirAll(filepath.Dir(uevent), 0755), IsNil)
+	c.Assert(ioutil.WriteFile(uevent, []byte("DEVNAME="+devname+"\n"), 0644), IsNil)
+}
+
+func (r fakeRoot) removeDisk(c *C, name string) {
+	c.Assert(os.RemoveAll(filepath.Join(string(r), sysFSBLOCKDEVICES, name)), IsNil)
+}
+
+func (s *MySuite) TestWatcherPoll(c *C) {
+	root := newFakeRoot(c)
+	defer os.RemoveAll(string(root))
+
+	w, err := NewWatcher(string(root), time.Second)
+	c.Assert(err, IsNil)
+	clock := &manualClock{now: time.Unix(1000, 0)}
+	w.clock = clock
+
+	// No change, no events.
+	events, err := w.poll()
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 0)
+
+	// A disk is plugged in and mounted.
+	root.addDisk(c, "sdc", "8:32", "sdc")
+	root.setMounts(c, mountRoot+mountDisk1+mountDisk2)
+	events, err = w.poll()
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 2)
+	c.Assert(events[0], DeepEquals, Event{Type: Added, Device: "/dev/sdc", Time: time.Unix(1000, 0)})
+	c.Assert(events[1].Type, Equals, Added)
+	c.Assert(events[1].Dir, Equals, "/mnt/disk2")
+	c.Assert(events[1].Device, Equals, "/dev/sdc")
+	c.Assert(events[1].Mount.ID, Equals, 31)
+
+	// The filesystem hits an error and goes read-only.
+	root.setMounts(c, mountRoot+mountDisk1+
+		"31 22 8:32 / /mnt/disk2 rw,noatime shared:21 - xfs /dev/sdc ro,attr2\n")
+	events, err = w.poll()
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Type, Equals, ReadOnly)
+	c.Assert(events[0].Dir, Equals, "/mnt/disk2")
+
+	// Remounted read-write, then remounted with a new mount ID.
+	root.setMounts(c, mountRoot+mountDisk1+mountDisk2)
+	events, err = w.poll()
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Type, Equals, Remounted)
+	root.setMounts(c, mountRoot+mountDisk1+
+		"40 22 8:32 / /mnt/disk2 rw,noatime shared:21 - xfs /dev/sdc rw,attr2\n")
+	events, err = w.poll()
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Type, Equals, Remounted)
+	c.Assert(events[0].Mount.ID, Equals, 40)
+
+	// The disk is pulled, the last known mount is reported.
+	root.removeDisk(c, "sdb")
+	root.setMounts(c, mountRoot+
+		"40 22 8:32 / /mnt/disk2 rw,noatime shared:21 - xfs /dev/sdc rw,attr2\n")
+	events, err = w.poll()
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 2)
+	c.Assert(events[0], DeepEquals, Event{Type: Removed, Device: "/dev/sdb", Time: time.Unix(1000, 0)})
+	c.Assert(events[1].Type, Equals, Removed)
+	c.Assert(events[1].Dir, Equals, "/mnt/disk1")
+	c.Assert(events[1].Device, Equals, "/dev/sdb")
+	c.Assert(events[1].Mount.ID, Equals, 30)
+
+	// An unreadable mount table is an error and keeps the state.
+	c.Assert(os.Remove(filepath.Join(string(root), procSELFMOUNTINFO)), IsNil)
+	_, err = w.poll()
+	c.Assert(err, NotNil)
+	root.setMounts(c, mountRoot+
+		"40 22 8:32 / /mnt/disk2 rw,noatime shared:21 - xfs /dev/sdc rw,attr2\n")
+	events, err = w.poll()
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 0)
+}
+
+func (s *MySuite) TestWatcherStart(c *C) {
+	root := newFakeRoot(c)
+	defer os.RemoveAll(string(root))
+
+	w, err := NewWatcher(string(root), time.Second)
+	c.Assert(err, IsNil)
+	clock := &manualClock{now: time.Unix(1000, 0)}
+	w.clock = clock
+	w.Start()
+	w.Start()
+
+	root.removeDisk(c, "sdb")
+	root.setMounts(c, mountRoot)
+
+	// Nothing happens before the interval has passed.
+	clock.waitWaiters(c, 1)
+	clock.Advance(500 * time.Millisecond)
+	select {
+	case event := <-w.Events():
+		c.Fatalf("unexpected event %v", event)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	var types []EventType
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-w.Events():
+			c.Assert(event.Time, Equals, time.Unix(1001, 0))
+			types = append(types, event.Type)
+		case <-time.After(5 * time.Second):
+			c.Fatal("no event")
+		}
+	}
+	c.Assert(types, DeepEquals, []EventType{Removed, Removed})
+	c.Assert(Removed.String(), Equals, "Removed")
+
+	w.Stop()
+	w.Stop()
+	_, ok := <-w.Events()
+	c.Assert(ok, Equals, false)
+}
+
+func (s *MySuite) TestWatcherMissingRoot(c *C) {
+	_, err := NewWatcher("testdata/does-not-exist", time.Second)
+	c.Assert(err, NotNil)
+}
@@ -0,0 +1,202 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Paths relative to the root of the filesystem, see ReadMountInfo.
+const (
+	procSELFMOUNTINFO = "/proc/self/mountinfo"
+	sysFSDEVBLOCK     = "/sys/dev/block"
+	devROOT           = "/dev"
+)
+
+// ErrMalformedMountInfo - a line of mountinfo could not be parsed.
+var ErrMalformedMountInfo = errors.New("malformed mountinfo line")
+
+// ParseMountInfo parses the mount table in the format of
+// /proc/self/mountinfo, see proc(5):
+//
+//	36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+//	(1)(2)(3)   (4)   (5)      (6)      (7)   (8) (9)   (10)         (11)
+//
+// Mounts are returned in the order of the table, Device is not set.
+func ParseMountInfo(r io.Reader) ([]Mountinfo, error) {
+	var mounts []Mountinfo
+	scanner := bufio.NewScanner(r)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		mount, err := parseMountInfoLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineno, err)
+		}
+		mounts = append(mounts, mount)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	markBindMounts(mounts)
+	return mounts, nil
+}
+
+// parseMountInfoLine parses one line of mountinfo.
+func parseMountInfoLine(line string) (Mountinfo, error) {
+	// Fields are separated by single spaces, spaces within fields are
+	// escaped.
+	fields := strings.Split(line, " ")
+	sep := -1
+	for i := 6; i < len(fields); i++ {
+		if fields[i] == "-" {
+			sep = i
+			break
+		}
+	}
+	if sep < 0 || len(fields) < sep+4 {
+		return Mountinfo{}, ErrMalformedMountInfo
+	}
+
+	mount := Mountinfo{}
+	var err error
+	if mount.ID, err = strconv.Atoi(fields[0]); err != nil {
+		return Mountinfo{}, ErrMalformedMountInfo
+	}
+	if mount.ParentID, err = strconv.Atoi(fields[1]); err != nil {
+		return Mountinfo{}, ErrMalformedMountInfo
+	}
+	if mount.Major, mount.Minor, err = parseDevNumber(fields[2]); err != nil {
+		return Mountinfo{}, ErrMalformedMountInfo
+	}
+	mount.Root = unescapeOctal(fields[3])
+	mount.Dir = unescapeOctal(fields[4])
+	mount.Opts = fields[5]
+	if sep > 6 {
+		mount.Optional = fields[6:sep]
+	}
+	mount.Type = unescapeOctal(fields[sep+1])
+	mount.FSName = unescapeOctal(fields[sep+2])
+	// Super options may contain escaped spaces only, join whatever
+	// follows to be lenient.
+	mount.SuperOpts = strings.Join(fields[sep+3:], " ")
+	return mount, nil
+}
+
+// parseDevNumber parses "major:minor".
+func parseDevNumber(s string) (major, minor int, err error) {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return 0, 0, ErrMalformedMountInfo
+	}
+	if major, err = strconv.Atoi(s[:i]); err != nil {
+		return 0, 0, err
+	}
+	if minor, err = strconv.Atoi(s[i+1:]); err != nil {
+		return 0, 0, err
+	}
+	return major, minor, nil
+}
+
+// unescapeOctal replaces the \ooo escapes used by the kernel for space,
+// tab, newline and backslash in mountinfo fields.
+func unescapeOctal(s string) string {
+	if strings.IndexByte(s, '\\') < 0 {
+		return s
+	}
+	var buf []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) && isOctal(s[i+1]) && isOctal(s[i+2]) && isOctal(s[i+3]) {
+			buf = append(buf, (s[i+1]-'0')<<6|(s[i+2]-'0')<<3|(s[i+3]-'0'))
+			i += 3
+			continue
+		}
+		buf = append(buf, s[i])
+	}
+	return string(buf)
+}
+
+func isOctal(c byte) bool {
+	return c >= '0' && c <= '7'
+}
+
+// markBindMounts sets Bind on every mount of a filesystem but the first
+// one mounting its topmost directory.
+func markBindMounts(mounts []Mountinfo) {
+	type devNumber struct{ major, minor int }
+	origin := make(map[devNumber]int)
+	for i, m := range mounts {
+		dev := devNumber{m.Major, m.Minor}
+		j, ok := origin[dev]
+		if !ok || len(m.Root) < len(mounts[j].Root) {
+			origin[dev] = i
+		}
+	}
+	for i, m := range mounts {
+		mounts[i].Bind = origin[devNumber{m.Major, m.Minor}] != i
+	}
+}
+
+// blockDevice returns the device node of the block device major:minor
+// from sysfs under root, empty if there is none.
+func blockDevice(root string, major, minor int) string {
+	name := fmt.Sprintf("%d:%d", major, minor)
+	uevent, err := ioutil.ReadFile(filepath.Join(root, sysFSDEVBLOCK, name, "uevent"))
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(uevent), "\n") {
+		if strings.HasPrefix(line, "DEVNAME=") {
+			return filepath.Join(devROOT, strings.TrimPrefix(line, "DEVNAME="))
+		}
+	}
+	return ""
+}
+
+// ReadMountInfo reads the mount table of the process from
+// /proc/self/mountinfo under root, and maps every mount to its backing
+// block device from /sys/dev/block under root.  root is "/" except in
+// tests.
+func ReadMountInfo(root string) ([]Mountinfo, error) {
+	f, err := os.Open(filepath.Join(root, procSELFMOUNTINFO))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	mounts, err := ParseMountInfo(f)
+	if err != nil {
+		return nil, err
+	}
+	for i := range mounts {
+		// Major 0 are anonymous devices, like tmpfs, nfs and overlay.
+		if mounts[i].Major != 0 {
+			mounts[i].Device = blockDevice(root, mounts[i].Major, mounts[i].Minor)
+		}
+	}
+	return mounts, nil
+}
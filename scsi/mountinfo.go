@@ -19,94 +19,76 @@
 package scsi
 
 import (
-	"bufio"
 	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strconv"
-	"strings"
 	"syscall"
-
-	"github.com/minio/minio/pkg/iodine"
 )
 
-var supportedFSType = map[string]bool{
-	"ext4":  true,
-	"xfs":   true,
-	"ext3":  true,
-	"btrfs": true,
-	"tmpfs": true,
-	"nfs":   true,
-}
-
-func isSupportedType(t string) bool {
-	_, ok := supportedFSType[t]
-	return ok
-}
-
-// GetMountInfo - get mount info map
+// GetMountInfo - get mount info map of the mounts of supported
+// filesystems, keyed by the mount source with symlinks resolved, which
+// is also set as FSName.  See GetMountInfoByDir for all mounts.
 func GetMountInfo() (map[string]Mountinfo, error) {
-	f, err := os.Open("/etc/mtab")
+	mounts, err := ReadMountInfo("/")
 	if err != nil {
-		return nil, iodine.New(err, nil)
+		return nil, err
 	}
 	mntEnt := make(map[string]Mountinfo)
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		mtabEnt := strings.Split(scanner.Text(), " ")
-		mntInfo := Mountinfo{}
-		if len(mtabEnt) == 6 {
-			var err error
-			if !isSupportedType(mtabEnt[2]) {
-				continue
-			}
-			mntInfo.FSName, err = filepath.EvalSymlinks(mtabEnt[0])
-			if err != nil {
-				continue
-			}
-			mntInfo.Dir = mtabEnt[1]
-			mntInfo.Type = mtabEnt[2]
-			mntInfo.Opts = mtabEnt[3]
-			mntInfo.Freq, err = strconv.Atoi(mtabEnt[4])
-			if err != nil {
-				continue
-			}
-			mntInfo.Passno, err = strconv.Atoi(mtabEnt[5])
-			if err != nil {
-				continue
-			}
-			mntEnt[mntInfo.FSName] = mntInfo
+	for _, mount := range mounts {
+		if !isSupportedType(mount.Type) {
+			continue
+		}
+		mount.FSName, err = filepath.EvalSymlinks(mount.FSName)
+		if err != nil {
+			continue
 		}
+		mntEnt[mount.FSName] = mount
+	}
+	return mntEnt, nil
+}
+
+// GetMountInfoByDir - get mount info map of all mounts, keyed by mount
+// point.  A mount point mounted over several times maps to the visible,
+// last, mount.
+func GetMountInfoByDir() (map[string]Mountinfo, error) {
+	mounts, err := ReadMountInfo("/")
+	if err != nil {
+		return nil, err
+	}
+	mntEnt := make(map[string]Mountinfo)
+	for _, mount := range mounts {
+		mntEnt[mount.Dir] = mount
 	}
 	return mntEnt, nil
 }
 
-// IsUsable provides a comprehensive way of knowing if the provided mountPath is mounted and writable
+// IsUsable provides a comprehensive way of knowing if the provided mountPath is mounted and writable, see
+// Qualify for a full qualification of a disk.
 func IsUsable(mountPath string) (bool, error) {
 	mntpoint, err := os.Stat(mountPath)
 	if err != nil {
-		return false, iodine.New(err, nil)
+		return false, err
 	}
 	parent, err := os.Stat(filepath.Join(mountPath, ".."))
 	if err != nil {
-		return false, iodine.New(err, nil)
+		return false, err
 	}
 	mntpointSt := mntpoint.Sys().(*syscall.Stat_t)
 	parentSt := parent.Sys().(*syscall.Stat_t)
 
 	if mntpointSt.Dev == parentSt.Dev {
-		return false, iodine.New(errors.New("not mounted"), nil)
+		return false, errors.New("not mounted")
 	}
 	testFile, err := ioutil.TempFile(mountPath, "writetest-")
 	if err != nil {
-		return false, iodine.New(err, nil)
+		return false, err
 	}
 	testFileName := testFile.Name()
 	// close the file, to avoid leaky fd's
 	testFile.Close()
 	if err := os.Remove(testFileName); err != nil {
-		return false, iodine.New(err, nil)
+		return false, err
 	}
 	return true, nil
 }
@@ -21,3 +21,9 @@ func GetMountInfo() (map[string]Mountinfo, error) {
 	// Stub implementation; returns an empty map
 	return make(map[string]Mountinfo), nil
 }
+
+// GetMountInfoByDir - get mount info map keyed by mount point
+func GetMountInfoByDir() (map[string]Mountinfo, error) {
+	// Stub implementation; returns an empty map
+	return make(map[string]Mountinfo), nil
+}
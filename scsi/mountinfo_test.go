@@ -0,0 +1,112 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"os"
+	"strings"
+
+	. "github.com/minio/check"
+)
+
+func (s *MySuite) TestReadMountInfo(c *C) {
+	mounts, err := ReadMountInfo("testdata/mountinfo")
+	c.Assert(err, IsNil)
+	c.Assert(mounts, HasLen, 10)
+
+	c.Assert(mounts[0], DeepEquals, Mountinfo{
+		ID:        22,
+		ParentID:  1,
+		Major:     8,
+		Minor:     1,
+		Root:      "/",
+		Dir:       "/",
+		Opts:      "rw,relatime",
+		Optional:  []string{"shared:1"},
+		Type:      "ext4",
+		FSName:    "/dev/sda1",
+		SuperOpts: "rw,errors=remount-ro",
+		Device:    "/dev/sda1",
+	})
+
+	byDir := make(map[string]Mountinfo)
+	for _, m := range mounts {
+		byDir[m.Dir] = m
+	}
+
+	// Octal escapes.
+	disk, ok := byDir["/mnt/disk 1"]
+	c.Assert(ok, Equals, true)
+	c.Assert(disk.Device, Equals, "/dev/sdb")
+	c.Assert(disk.Bind, Equals, false)
+	_, ok = byDir["/mnt/back\\slash\ttab"]
+	c.Assert(ok, Equals, true)
+
+	// Several optional fields, and none.
+	nvme := byDir["/mnt/nvme"]
+	c.Assert(nvme.Optional, DeepEquals, []string{"master:3", "propagate_from:2", "unbindable"})
+	c.Assert(nvme.Device, Equals, "/dev/nvme0n1p2")
+	c.Assert(byDir["/mnt/nfs"].Optional, IsNil)
+
+	// Virtual filesystems have no block device.
+	c.Assert(byDir["/proc"].Device, Equals, "")
+	c.Assert(byDir["/mnt/nfs"].Device, Equals, "")
+	c.Assert(byDir["/mnt/nfs"].FSName, Equals, "server:/export")
+
+	// Bind mounts, of a subdirectory and of the root of a filesystem.
+	export := byDir["/srv/export"]
+	c.Assert(export.Bind, Equals, true)
+	c.Assert(export.Root, Equals, "/export/data")
+	c.Assert(export.Device, Equals, "/dev/sdb")
+	c.Assert(byDir["/mnt/rootbind"].Bind, Equals, true)
+	c.Assert(byDir["/"].Bind, Equals, false)
+}
+
+func (s *MySuite) TestParseMountInfoMalformed(c *C) {
+	f, err := os.Open("testdata/mountinfo-malformed")
+	c.Assert(err, IsNil)
+	defer f.Close()
+	_, err = ParseMountInfo(f)
+	c.Assert(err, NotNil)
+	c.Assert(strings.HasPrefix(err.Error(), "line 2:"), Equals, true)
+
+	testCases := []string{
+		"",
+		"22 1 8:1 / / rw - ext4",
+		"x 1 8:1 / / rw - ext4 /dev/sda1 rw",
+		"22 1 8-1 / / rw - ext4 /dev/sda1 rw",
+	}
+	for _, testCase := range testCases {
+		_, err := parseMountInfoLine(testCase)
+		c.Assert(err, Equals, ErrMalformedMountInfo, Commentf("%q", testCase))
+	}
+}
+
+func (s *MySuite) TestUnescapeOctal(c *C) {
+	testCases := []struct {
+		escaped, unescaped string
+	}{
+		{"/mnt/plain", "/mnt/plain"},
+		{"/mnt/a\\040b", "/mnt/a b"},
+		{"/mnt/a\\012b\\134", "/mnt/a\nb\\"},
+		{"/mnt/a\\04", "/mnt/a\\04"},
+		{"/mnt/a\\999", "/mnt/a\\999"},
+	}
+	for _, testCase := range testCases {
+		c.Assert(unescapeOctal(testCase.escaped), Equals, testCase.unescaped)
+	}
+}
@@ -0,0 +1,429 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"time"
+	"unsafe"
+)
+
+var supportedFSType = map[string]bool{
+	"ext4":  true,
+	"xfs":   true,
+	"ext3":  true,
+	"btrfs": true,
+	"tmpfs": true,
+	"nfs":   true,
+	"apfs":  true,
+	"hfs":   true,
+}
+
+func isSupportedType(t string) bool {
+	_, ok := supportedFSType[t]
+	return ok
+}
+
+// ErrQualifyNotSupported is returned by Qualify where statfs is not
+// implemented.
+var ErrQualifyNotSupported = errors.New("disk qualification is not supported on this platform")
+
+// directIOAlignment is the buffer, offset and size alignment used for
+// O_DIRECT I/O, it satisfies the logical block size of all common devices.
+const directIOAlignment = 4096
+
+// QualifyOptions control the size of the benchmark run by Qualify and the
+// thresholds it is held against.  Zero sizes take their defaults, zero
+// thresholds are not checked.
+type QualifyOptions struct {
+	NoBenchmark     bool  // only check the filesystem, measure nothing
+	FileSize        int64 // size of the test file, at most a tenth of the free space, default 64MiB
+	BlockSize       int   // size of sequential I/Os, default 1MiB
+	RandomBlockSize int   // size of random I/Os, default 4KiB
+	RandomIOs       int   // number of random reads and of random writes, default 256
+	Fsyncs          int   // number of fsync latency samples, default 16
+
+	MinFreeBytes      uint64
+	MinFreeInodes     uint64
+	RequireMountPoint bool
+	RequireDirectIO   bool
+	RequireFSType     bool    // filesystem type must be a supported one
+	MinSeqWrite       float64 // bytes per second
+	MinSeqRead        float64 // bytes per second
+	MinRandWriteIOPS  float64
+	MinRandReadIOPS   float64
+	MaxFsyncLatency   time.Duration // average latency
+}
+
+// DefaultQualifyOptions are the thresholds an export is expected to meet.
+var DefaultQualifyOptions = QualifyOptions{
+	MinFreeBytes:     1 << 30,
+	MinFreeInodes:    10000,
+	RequireFSType:    true,
+	MinSeqWrite:      10 << 20,
+	MinSeqRead:       10 << 20,
+	MinRandWriteIOPS: 50,
+	MinRandReadIOPS:  50,
+	MaxFsyncLatency:  500 * time.Millisecond,
+}
+
+func (o QualifyOptions) withDefaults() (QualifyOptions, error) {
+	if o.FileSize <= 0 {
+		o.FileSize = 64 << 20
+	}
+	if o.BlockSize <= 0 {
+		o.BlockSize = 1 << 20
+	}
+	if o.RandomBlockSize <= 0 {
+		o.RandomBlockSize = 4 << 10
+	}
+	if o.RandomIOs <= 0 {
+		o.RandomIOs = 256
+	}
+	if o.Fsyncs <= 0 {
+		o.Fsyncs = 16
+	}
+	// O_DIRECT needs aligned sizes.
+	o.BlockSize = alignUp(o.BlockSize)
+	o.RandomBlockSize = alignUp(o.RandomBlockSize)
+	if o.FileSize < int64(o.BlockSize) || o.FileSize < int64(o.RandomBlockSize) {
+		return o, fmt.Errorf("qualify: file size %d is smaller than the block sizes %d and %d",
+			o.FileSize, o.BlockSize, o.RandomBlockSize)
+	}
+	return o, nil
+}
+
+// Check is the outcome of a single qualification check.
+type Check struct {
+	Name   string
+	Pass   bool
+	Detail string
+}
+
+// QualifyReport is the result of Qualify.  Throughputs are in bytes per
+// second.  Without DirectIO reads are likely served from the page cache
+// and overstate the device.
+type QualifyReport struct {
+	Path       string
+	FSType     string
+	Total      uint64 // bytes
+	Free       uint64 // bytes available to unprivileged users
+	Inodes     uint64 // zero if the filesystem has no fixed inode count
+	FreeInodes uint64
+	MountPoint bool
+	DirectIO   bool
+
+	FileSize      int64
+	SeqWrite      float64
+	SeqRead       float64
+	RandWriteIOPS float64
+	RandReadIOPS  float64
+	FsyncAvg      time.Duration
+	FsyncMax      time.Duration
+
+	Checks []Check
+}
+
+// Pass reports whether all checks passed.
+func (r QualifyReport) Pass() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the checks which did not pass.
+func (r QualifyReport) Failed() []Check {
+	var failed []Check
+	for _, check := range r.Checks {
+		if !check.Pass {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// fsStat is the filesystem information Qualify needs from statfs.
+type fsStat struct {
+	Type       string
+	Total      uint64
+	Free       uint64
+	Inodes     uint64
+	FreeInodes uint64
+}
+
+// Qualify goes beyond IsUsable, it checks the free space, inodes and type
+// of the filesystem holding path and whether it supports O_DIRECT, then
+// measures sequential and random throughput and fsync latency with a
+// bounded test file, which is removed afterwards.  The benchmark is skipped
+// when a tenth of the free space cannot hold the test file, its thresholds
+// then fail.  An error is returned only if the checks could not be run at
+// all, failing checks are recorded in the report.
+func Qualify(path string, opts QualifyOptions) (QualifyReport, error) {
+	report := QualifyReport{Path: path}
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return report, err
+	}
+
+	st, err := statFS(path)
+	if err != nil {
+		return report, err
+	}
+	report.FSType = st.Type
+	report.Total = st.Total
+	report.Free = st.Free
+	report.Inodes = st.Inodes
+	report.FreeInodes = st.FreeInodes
+	if report.MountPoint, err = isMountPoint(path); err != nil {
+		return report, err
+	}
+
+	if opts.NoBenchmark {
+		report.Checks = qualifyChecks(opts, report, false)
+		return report, nil
+	}
+
+	// Never take more than a tenth of the free space, if that does not
+	// hold a test file the benchmark is skipped rather than overrunning it.
+	report.FileSize = opts.FileSize
+	if limit := int64(st.Free / 10); report.FileSize > limit {
+		report.FileSize = limit
+	}
+	report.FileSize -= report.FileSize % int64(opts.BlockSize)
+	if report.FileSize < int64(opts.BlockSize) || report.FileSize < int64(opts.RandomBlockSize) {
+		report.FileSize = 0
+		report.Checks = qualifyChecks(opts, report, false)
+		return report, nil
+	}
+
+	if err = benchmark(path, opts, &report); err != nil {
+		return report, err
+	}
+	report.Checks = qualifyChecks(opts, report, true)
+	return report, nil
+}
+
+// qualifyChecks holds the report against the thresholds of opts.  Without
+// measurements the benchmark checks fail if they have a threshold, unless
+// the benchmark was turned off.
+func qualifyChecks(opts QualifyOptions, r QualifyReport, measured bool) []Check {
+	checks := []Check{{
+		Name:   "free space",
+		Pass:   r.Free >= opts.MinFreeBytes,
+		Detail: fmt.Sprintf("%d bytes free, need %d", r.Free, opts.MinFreeBytes),
+	}}
+	if r.Inodes == 0 {
+		checks = append(checks, Check{
+			Name:   "free inodes",
+			Pass:   true,
+			Detail: "inodes are allocated dynamically",
+		})
+	} else {
+		checks = append(checks, Check{
+			Name:   "free inodes",
+			Pass:   r.FreeInodes >= opts.MinFreeInodes,
+			Detail: fmt.Sprintf("%d inodes free, need %d", r.FreeInodes, opts.MinFreeInodes),
+		})
+	}
+	checks = append(checks,
+		Check{
+			Name:   "filesystem type",
+			Pass:   !opts.RequireFSType || isSupportedType(r.FSType),
+			Detail: fmt.Sprintf("%s, supported: %t", r.FSType, isSupportedType(r.FSType)),
+		},
+		Check{
+			Name:   "mount point",
+			Pass:   !opts.RequireMountPoint || r.MountPoint,
+			Detail: fmt.Sprintf("mount point: %t", r.MountPoint),
+		},
+	)
+	if opts.NoBenchmark {
+		return checks
+	}
+	if !measured {
+		const detail = "not measured, too little free space for a test file"
+		return append(checks,
+			Check{Name: "direct I/O", Pass: !opts.RequireDirectIO, Detail: detail},
+			Check{Name: "sequential write", Pass: opts.MinSeqWrite == 0, Detail: detail},
+			Check{Name: "sequential read", Pass: opts.MinSeqRead == 0, Detail: detail},
+			Check{Name: "random write", Pass: opts.MinRandWriteIOPS == 0, Detail: detail},
+			Check{Name: "random read", Pass: opts.MinRandReadIOPS == 0, Detail: detail},
+			Check{Name: "fsync latency", Pass: opts.MaxFsyncLatency == 0, Detail: detail},
+		)
+	}
+	return append(checks,
+		Check{
+			Name:   "direct I/O",
+			Pass:   !opts.RequireDirectIO || r.DirectIO,
+			Detail: fmt.Sprintf("O_DIRECT: %t", r.DirectIO),
+		},
+		minCheck("sequential write", r.SeqWrite, opts.MinSeqWrite, "bytes/s"),
+		minCheck("sequential read", r.SeqRead, opts.MinSeqRead, "bytes/s"),
+		minCheck("random write", r.RandWriteIOPS, opts.MinRandWriteIOPS, "IOPS"),
+		minCheck("random read", r.RandReadIOPS, opts.MinRandReadIOPS, "IOPS"),
+		Check{
+			Name:   "fsync latency",
+			Pass:   opts.MaxFsyncLatency == 0 || r.FsyncAvg <= opts.MaxFsyncLatency,
+			Detail: fmt.Sprintf("%v average, %v max, limit %v", r.FsyncAvg, r.FsyncMax, opts.MaxFsyncLatency),
+		},
+	)
+}
+
+func minCheck(name string, value, min float64, unit string) Check {
+	return Check{
+		Name:   name,
+		Pass:   value >= min,
+		Detail: fmt.Sprintf("%.0f %s, need %.0f", value, unit, min),
+	}
+}
+
+// benchmark fills in the direct I/O support and the measurements of the
+// report, using a test file in path.
+func benchmark(path string, opts QualifyOptions, r *QualifyReport) error {
+	tmp, err := ioutil.TempFile(path, "qualify-")
+	if err != nil {
+		return err
+	}
+	name := tmp.Name()
+	tmp.Close()
+	defer os.Remove(name)
+
+	f, err := openDirect(name)
+	if err == nil {
+		r.DirectIO = probeDirect(f)
+		if !r.DirectIO {
+			f.Close()
+		}
+	}
+	if !r.DirectIO {
+		if f, err = os.OpenFile(name, os.O_RDWR, 0); err != nil {
+			return err
+		}
+	}
+	defer f.Close()
+
+	buf := alignedBuffer(opts.BlockSize)
+	for i := range buf {
+		buf[i] = byte(i)
+	}
+
+	// Sequential write, including the fsync making it durable.
+	start := time.Now()
+	for off := int64(0); off < r.FileSize; off += int64(len(buf)) {
+		if _, err = f.WriteAt(buf, off); err != nil {
+			return err
+		}
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	r.SeqWrite = throughput(r.FileSize, time.Since(start))
+
+	// Sequential read.
+	start = time.Now()
+	for off := int64(0); off < r.FileSize; off += int64(len(buf)) {
+		if _, err = f.ReadAt(buf, off); err != nil {
+			return err
+		}
+	}
+	r.SeqRead = throughput(r.FileSize, time.Since(start))
+
+	// Random writes and reads at block aligned offsets, the sequence is
+	// fixed so that runs are comparable.
+	rbuf := alignedBuffer(opts.RandomBlockSize)
+	copy(rbuf, buf)
+	blocks := r.FileSize / int64(len(rbuf))
+	rnd := rand.New(rand.NewSource(1))
+	start = time.Now()
+	for i := 0; i < opts.RandomIOs; i++ {
+		if _, err = f.WriteAt(rbuf, rnd.Int63n(blocks)*int64(len(rbuf))); err != nil {
+			return err
+		}
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	r.RandWriteIOPS = iops(opts.RandomIOs, time.Since(start))
+
+	start = time.Now()
+	for i := 0; i < opts.RandomIOs; i++ {
+		if _, err = f.ReadAt(rbuf, rnd.Int63n(blocks)*int64(len(rbuf))); err != nil {
+			return err
+		}
+	}
+	r.RandReadIOPS = iops(opts.RandomIOs, time.Since(start))
+
+	// fsync latency of a small overwrite, as done for every object commit.
+	var total time.Duration
+	for i := 0; i < opts.Fsyncs; i++ {
+		if _, err = f.WriteAt(rbuf, 0); err != nil {
+			return err
+		}
+		start = time.Now()
+		if err = f.Sync(); err != nil {
+			return err
+		}
+		d := time.Since(start)
+		total += d
+		if d > r.FsyncMax {
+			r.FsyncMax = d
+		}
+	}
+	r.FsyncAvg = total / time.Duration(opts.Fsyncs)
+	return nil
+}
+
+// probeDirect reports whether aligned I/O succeeds on a file opened for
+// direct I/O, some filesystems accept the flag and fail the I/O.
+func probeDirect(f *os.File) bool {
+	buf := alignedBuffer(directIOAlignment)
+	if _, err := f.WriteAt(buf, 0); err != nil {
+		return false
+	}
+	if _, err := f.ReadAt(buf, 0); err != nil {
+		return false
+	}
+	return true
+}
+
+// alignedBuffer returns a zeroed buffer of size bytes starting at a
+// directIOAlignment boundary.
+func alignedBuffer(size int) []byte {
+	buf := make([]byte, size+directIOAlignment)
+	off := 0
+	if rem := int(uintptr(unsafe.Pointer(&buf[0])) & (directIOAlignment - 1)); rem != 0 {
+		off = directIOAlignment - rem
+	}
+	return buf[off : off+size]
+}
+
+func alignUp(n int) int {
+	return (n + directIOAlignment - 1) &^ (directIOAlignment - 1)
+}
+
+func throughput(n int64, d time.Duration) float64 {
+	if d <= 0 {
+		d = time.Nanosecond
+	}
+	return float64(n) / d.Seconds()
+}
+
+func iops(n int, d time.Duration) float64 {
+	return throughput(int64(n), d)
+}
@@ -0,0 +1,73 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+func statFS(path string) (fsStat, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return fsStat{}, err
+	}
+	var name []byte
+	for _, c := range st.Fstypename {
+		if c == 0 {
+			break
+		}
+		name = append(name, byte(c))
+	}
+	bsize := uint64(st.Bsize)
+	return fsStat{
+		Type:       string(name),
+		Total:      st.Blocks * bsize,
+		Free:       st.Bavail * bsize,
+		Inodes:     st.Files,
+		FreeInodes: st.Ffree,
+	}, nil
+}
+
+// openDirect bypasses the buffer cache with F_NOCACHE, darwin has no
+// O_DIRECT.
+func openDirect(name string) (*os.File, error) {
+	f, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, e := syscall.Syscall(syscall.SYS_FCNTL, f.Fd(), syscall.F_NOCACHE, 1); e != 0 {
+		f.Close()
+		return nil, e
+	}
+	return f, nil
+}
+
+// isMountPoint reports whether path is on a different device than its
+// parent.
+func isMountPoint(path string) (bool, error) {
+	mntpoint, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	parent, err := os.Stat(filepath.Join(path, ".."))
+	if err != nil {
+		return false, err
+	}
+	return mntpoint.Sys().(*syscall.Stat_t).Dev != parent.Sys().(*syscall.Stat_t).Dev, nil
+}
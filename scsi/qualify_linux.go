@@ -0,0 +1,78 @@
+// +build linux
+
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// fsMagic maps statfs f_type magic numbers, see statfs(2), to the names
+// used in /proc/self/mountinfo.
+var fsMagic = map[uint32]string{
+	0xef53:     "ext4", // shared by ext2, ext3 and ext4
+	0x58465342: "xfs",
+	0x9123683e: "btrfs",
+	0x01021994: "tmpfs",
+	0x6969:     "nfs",
+	0x2fc12fc1: "zfs",
+	0x794c7630: "overlay",
+	0xf2f52010: "f2fs",
+	0x65735546: "fuse",
+	0x4d44:     "vfat",
+}
+
+func statFS(path string) (fsStat, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return fsStat{}, err
+	}
+	fsType, ok := fsMagic[uint32(st.Type)]
+	if !ok {
+		fsType = fmt.Sprintf("0x%x", uint32(st.Type))
+	}
+	bsize := uint64(st.Bsize)
+	return fsStat{
+		Type:       fsType,
+		Total:      uint64(st.Blocks) * bsize,
+		Free:       uint64(st.Bavail) * bsize,
+		Inodes:     uint64(st.Files),
+		FreeInodes: uint64(st.Ffree),
+	}, nil
+}
+
+func openDirect(name string) (*os.File, error) {
+	return os.OpenFile(name, os.O_RDWR|syscall.O_DIRECT, 0)
+}
+
+// isMountPoint reports whether path is on a different device than its
+// parent, like IsUsable.
+func isMountPoint(path string) (bool, error) {
+	mntpoint, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	parent, err := os.Stat(filepath.Join(path, ".."))
+	if err != nil {
+		return false, err
+	}
+	return mntpoint.Sys().(*syscall.Stat_t).Dev != parent.Sys().(*syscall.Stat_t).Dev, nil
+}
@@ -1,7 +1,7 @@
-// +build darwin,amd64
+// +build !linux,!darwin
 
 /*
- * Minio Cloud Storage, (C) 2014-2016 Minio, Inc.
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -16,6 +16,18 @@
  * limitations under the License.
  */
 
-package sha1
+package scsi
 
-//go:generate yasm -f macho64 -DINTEL_SHA1_UPDATE_FUNCNAME=_sha1_update_intel sha1_sse3_amd64.asm -o sha1_sse3_amd64.syso
+import "os"
+
+func statFS(path string) (fsStat, error) {
+	return fsStat{}, ErrQualifyNotSupported
+}
+
+func openDirect(name string) (*os.File, error) {
+	return nil, ErrQualifyNotSupported
+}
+
+func isMountPoint(path string) (bool, error) {
+	return false, ErrQualifyNotSupported
+}
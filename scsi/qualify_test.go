@@ -0,0 +1,140 @@
+// +build linux darwin
+
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package scsi
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+	"unsafe"
+
+	. "github.com/minio/check"
+)
+
+// smallQualify keeps the benchmark fast, one block of each size.
+var smallQualify = QualifyOptions{
+	FileSize:  1 << 20,
+	BlockSize: 256 << 10,
+	RandomIOs: 8,
+	Fsyncs:    2,
+}
+
+func (s *MySuite) TestQualify(c *C) {
+	dir, err := ioutil.TempDir("", "qualify-")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	report, err := Qualify(dir, smallQualify)
+	c.Assert(err, IsNil)
+	c.Assert(report.Path, Equals, dir)
+	c.Assert(report.FSType, Not(Equals), "")
+	c.Assert(report.Total > 0, Equals, true)
+	c.Assert(report.Free > 0, Equals, true)
+	c.Assert(report.MountPoint, Equals, false)
+	c.Assert(report.FileSize <= 1<<20, Equals, true)
+	c.Assert(report.SeqWrite > 0, Equals, true)
+	c.Assert(report.SeqRead > 0, Equals, true)
+	c.Assert(report.RandWriteIOPS > 0, Equals, true)
+	c.Assert(report.RandReadIOPS > 0, Equals, true)
+	c.Assert(report.FsyncMax >= report.FsyncAvg, Equals, true)
+	// No thresholds, everything passes.
+	c.Assert(report.Failed(), HasLen, 0)
+	c.Assert(report.Pass(), Equals, true)
+
+	// The test file is removed.
+	entries, err := ioutil.ReadDir(dir)
+	c.Assert(err, IsNil)
+	c.Assert(entries, HasLen, 0)
+}
+
+func (s *MySuite) TestQualifyThresholds(c *C) {
+	dir, err := ioutil.TempDir("", "qualify-")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	opts := smallQualify
+	opts.MinFreeBytes = 1 << 62
+	opts.RequireMountPoint = true
+	opts.MinSeqWrite = 1 << 62
+	opts.MaxFsyncLatency = time.Nanosecond
+	report, err := Qualify(dir, opts)
+	c.Assert(err, IsNil)
+	c.Assert(report.Pass(), Equals, false)
+
+	var failed []string
+	for _, check := range report.Failed() {
+		failed = append(failed, check.Name)
+	}
+	c.Assert(failed, DeepEquals, []string{"free space", "mount point", "sequential write", "fsync latency"})
+}
+
+func (s *MySuite) TestQualifyBlockSizes(c *C) {
+	dir, err := ioutil.TempDir("", "qualify-")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	// Random I/Os larger than the sequential ones.
+	opts := smallQualify
+	opts.RandomBlockSize = 512 << 10
+	report, err := Qualify(dir, opts)
+	c.Assert(err, IsNil)
+	c.Assert(report.RandWriteIOPS > 0, Equals, true)
+	c.Assert(report.Pass(), Equals, true)
+
+	// A test file which cannot hold a single random I/O.
+	opts.RandomBlockSize = 2 << 20
+	_, err = Qualify(dir, opts)
+	c.Assert(err, NotNil)
+}
+
+func (s *MySuite) TestQualifyNoBenchmark(c *C) {
+	dir, err := ioutil.TempDir("", "qualify-")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	opts := DefaultQualifyOptions
+	opts.NoBenchmark = true
+	opts.MinFreeBytes = 0
+	report, err := Qualify(dir, opts)
+	c.Assert(err, IsNil)
+	c.Assert(report.FileSize, Equals, int64(0))
+	c.Assert(report.SeqWrite, Equals, float64(0))
+
+	var names []string
+	for _, check := range report.Checks {
+		names = append(names, check.Name)
+	}
+	c.Assert(names, DeepEquals, []string{"free space", "free inodes", "filesystem type", "mount point"})
+}
+
+func (s *MySuite) TestQualifyMissing(c *C) {
+	_, err := Qualify("testdata/does-not-exist", smallQualify)
+	c.Assert(err, NotNil)
+}
+
+func (s *MySuite) TestAlignedBuffer(c *C) {
+	for _, size := range []int{512, 4096, 1 << 20} {
+		buf := alignedBuffer(size)
+		c.Assert(buf, HasLen, size)
+		c.Assert(uintptr(unsafe.Pointer(&buf[0]))%directIOAlignment, Equals, uintptr(0))
+	}
+	c.Assert(alignUp(1), Equals, 4096)
+	c.Assert(alignUp(4096), Equals, 4096)
+	c.Assert(alignUp(4097), Equals, 8192)
+}
@@ -16,47 +16,34 @@
 
 package scsi
 
-import (
-	"io/ioutil"
-	"os"
-	"path"
-	"strings"
-)
+// Disks is a list of disks keyed by device node, like /dev/sda
+type Disks map[string]Disk
 
-// Attributes Scsi device attributes
-type Attributes map[string]string
-
-// Disks is a list of scsis disks and attributes
-type Disks map[string]Attributes
-
-// Mountinfo container to capture /etc/mtab mount structure
-type Mountinfo struct {
-	FSName string /* name of mounted filesystem */
-	Dir    string /* filesystem path prefix */
-	Type   string /* mount type (see mntent.h) */
-	Opts   string /* mount options (see mntent.h) */
-	Freq   int    /* dump frequency in days */
-	Passno int    /* pass number on parallel fsck */
+// Get get disk scsi params
+func (d Disks) Get(disk string) Disk {
+	return d[disk]
 }
 
-func getattrs(scsiAttrPath string, scsiAttrList []string) map[string]string {
-	attrMap := make(map[string]string)
-	for _, attr := range scsiAttrList {
-		value, _ := ioutil.ReadFile(path.Join(scsiAttrPath, attr))
-		attrMap[attr] = strings.Trim(string(value[:]), "\n") // remove odd newlines
-	}
-	return attrMap
+// Len return len of total disks
+func (d Disks) Len() int {
+	return len(d)
 }
 
-func filterdisks(files []os.FileInfo) (scsidisks []string) {
-	for _, fi := range files {
-		if strings.Contains(fi.Name(), "host") {
-			continue
-		}
-		if strings.Contains(fi.Name(), "target") {
-			continue
-		}
-		scsidisks = append(scsidisks, fi.Name())
-	}
-	return scsidisks
+// Mountinfo container to capture /proc/self/mountinfo mount structure
+type Mountinfo struct {
+	ID        int      /* unique id of the mount */
+	ParentID  int      /* id of the parent mount */
+	Major     int      /* st_dev major of files on the filesystem */
+	Minor     int      /* st_dev minor */
+	Root      string   /* directory of the filesystem forming the root of the mount */
+	Dir       string   /* mount point */
+	Opts      string   /* per mount options */
+	Optional  []string /* optional fields, like shared:N or master:N */
+	Type      string   /* filesystem type */
+	FSName    string   /* mount source, filesystem specific */
+	SuperOpts string   /* per superblock options */
+	Device    string   /* backing block device, empty for virtual filesystems */
+	Bind      bool     /* bind mount of a filesystem mounted elsewhere */
+	Freq      int      /* dump frequency in days, not in mountinfo, always 0 */
+	Passno    int      /* fsck pass number, not in mountinfo, always 0 */
 }
@@ -36,4 +36,6 @@ func (s *MySuite) TestSCSI(c *C) {
 func (s *MySuite) TestMountInfo(c *C) {
 	_, err := GetMountInfo()
 	c.Assert(err, IsNil)
+	_, err = GetMountInfoByDir()
+	c.Assert(err, IsNil)
 }
@@ -1,7 +1,5 @@
-// +build windows,amd64
-
 /*
- * Minio Cloud Storage, (C) 2014-2016 Minio, Inc.
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
  *
  * Licensed under the Apache License, Version 2.0 (the "License");
  * you may not use this file except in compliance with the License.
@@ -18,4 +16,20 @@
 
 package sha1
 
-//go:generate yasm -f win64 -DWIN_ABI=1 sha1_sse3_amd64.asm -o sha1_sse3_amd64.syso
+import (
+	"crypto/hmac"
+	"hash"
+)
+
+// NewHMAC returns a new hash.Hash computing HMAC-SHA1 with the given
+// key, using the accelerated block functions of this package.
+func NewHMAC(key []byte) hash.Hash {
+	return hmac.New(New, key)
+}
+
+// SumHMAC - single caller HMAC-SHA1 helper
+func SumHMAC(key, data []byte) []byte {
+	h := NewHMAC(key)
+	h.Write(data)
+	return h.Sum(nil)
+}
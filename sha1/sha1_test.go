@@ -28,7 +28,10 @@
 package sha1
 
 import (
+	"bytes"
 	"crypto/rand"
+	stdsha1 "crypto/sha1"
+	"encoding"
 	"fmt"
 	"io"
 	"testing"
@@ -112,6 +115,82 @@ func TestBlockSize(t *testing.T) {
 	}
 }
 
+// Tests that the hash state can be saved and restored mid-stream.
+func TestGoldenMarshal(t *testing.T) {
+	for _, g := range golden {
+		h := New()
+		h2 := New()
+
+		io.WriteString(h, g.in[:len(g.in)/2])
+
+		state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Errorf("could not marshal: %v", err)
+			continue
+		}
+
+		if err = h2.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+			t.Errorf("could not unmarshal: %v", err)
+			continue
+		}
+
+		io.WriteString(h, g.in[len(g.in)/2:])
+		io.WriteString(h2, g.in[len(g.in)/2:])
+
+		if actual, actual2 := h.Sum(nil), h2.Sum(nil); !bytes.Equal(actual, actual2) {
+			t.Errorf("sha1(%q) = %x != marshaled %x", g.in, actual, actual2)
+		}
+	}
+}
+
+// Tests that the marshaled state is interchangeable with the standard library.
+func TestMarshalCompatibility(t *testing.T) {
+	for _, g := range golden {
+		h := New()
+		s := stdsha1.New()
+
+		io.WriteString(h, g.in[:len(g.in)/2])
+		io.WriteString(s, g.in[:len(g.in)/2])
+
+		state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatalf("could not marshal: %v", err)
+		}
+		stdState, err := s.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatalf("could not marshal: %v", err)
+		}
+		if !bytes.Equal(state, stdState) {
+			t.Fatalf("sha1(%q) state = %x want %x", g.in, state, stdState)
+		}
+
+		h2 := New()
+		if err = h2.(encoding.BinaryUnmarshaler).UnmarshalBinary(stdState); err != nil {
+			t.Fatalf("could not unmarshal: %v", err)
+		}
+		io.WriteString(h2, g.in[len(g.in)/2:])
+		if s := fmt.Sprintf("%x", h2.Sum(nil)); s != g.out {
+			t.Fatalf("sha1(%q) = %s want %s", g.in, s, g.out)
+		}
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	h := New()
+	state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		t.Fatalf("could not marshal: %v", err)
+	}
+	u := h.(encoding.BinaryUnmarshaler)
+	if err = u.UnmarshalBinary(state[:len(state)-1]); err == nil {
+		t.Error("UnmarshalBinary accepted truncated state")
+	}
+	state[0] = 'x'
+	if err = u.UnmarshalBinary(state); err == nil {
+		t.Error("UnmarshalBinary accepted invalid identifier")
+	}
+}
+
 // Tests that blockGeneric (pure Go) and block (in assembly for amd64, 386, arm) match.
 func TestBlockGeneric(t *testing.T) {
 	gen, asm := New().(*digest), New().(*digest)
@@ -124,6 +203,32 @@ func TestBlockGeneric(t *testing.T) {
 	}
 }
 
+// HMAC-SHA1 test vectors from RFC 2202.
+var hmacGolden = []struct {
+	key []byte
+	in  string
+	out string
+}{
+	{bytes.Repeat([]byte{0x0b}, 20), "Hi There", "b617318655057264e28bc0b6fb378c8ef146be00"},
+	{[]byte("Jefe"), "what do ya want for nothing?", "effcdf6ae5eb2fa2d27416d5f184df9c259a7c79"},
+	{bytes.Repeat([]byte{0xaa}, 80), "Test Using Larger Than Block-Size Key - Hash Key First", "aa4ae5e15272d00e95705637ce8a3b55ed402112"},
+	{bytes.Repeat([]byte{0xaa}, 80), "Test Using Larger Than Block-Size Key and Larger Than One Block-Size Data", "e8e99d0f45237d786d6bbaa7965c7808bbff1a91"},
+}
+
+func TestHMAC(t *testing.T) {
+	for i, g := range hmacGolden {
+		if s := fmt.Sprintf("%x", SumHMAC(g.key, []byte(g.in))); s != g.out {
+			t.Errorf("SumHMAC[%d] = %s want %s", i, s, g.out)
+		}
+		h := NewHMAC(g.key)
+		io.WriteString(h, g.in[:len(g.in)/2])
+		io.WriteString(h, g.in[len(g.in)/2:])
+		if s := fmt.Sprintf("%x", h.Sum(nil)); s != g.out {
+			t.Errorf("NewHMAC[%d] = %s want %s", i, s, g.out)
+		}
+	}
+}
+
 var bench = New()
 var buf = make([]byte, 1024*1024)
 
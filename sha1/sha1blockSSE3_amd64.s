@@ -0,0 +1,1287 @@
+//
+// Minio Cloud Storage, (C) 2016 Minio, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+//
+// SHA1 implementation for SSSE3, converted to Plan 9 assembly from
+// Intel's sha1_update_intel (Authors: Maxim Locktyukhin and Ronen Zohar):
+//
+//   https://software.intel.com/en-us/articles/improving-the-performance-of-the-secure-hash-algorithm-1
+//
+// License information of the original implementation:
+//   It is provided 'as is' and free for either commercial or
+//   non-commercial use.
+//
+// The nasm macros of the original have been expanded, rounds are
+// software pipelined with the message scheduling of 16 rounds ahead,
+// including the first 16 rounds of the next block.
+//
+// Register usage:
+//   CX, SI, DI, R12, DX - a, b, c, d, e
+//   AX, BX              - temporaries
+//   R8                  - K_XMM_AR
+//   R9                  - hash
+//   R10                 - current block
+//   R11                 - end of the last block
+//   X1-X8               - w[i-28] .. w[i] rotating window
+//   X0, X9, X11         - temporaries
+//   X10                 - byte swap mask
+//   0(SP) .. 63(SP)     - w[i] + k for the next 16 rounds
+//
+
+#include "textflag.h"
+
+// func blockSSE3(dig *digest, p []byte)
+TEXT ·blockSSE3(SB), $64-32
+	MOVQ dig+0(FP), R9
+	MOVQ p_base+8(FP), R10
+	MOVQ p_len+16(FP), R11
+	SHRQ $6, R11
+	JEQ  end
+	SHLQ $6, R11
+	ADDQ R10, R11 // end of the last block
+
+	LEAQ K_XMM_AR<>(SB), R8
+	MOVOU BSWAP_SHUFB_CTL<>(SB), X10
+
+	MOVL 0(R9), CX
+	MOVL 4(R9), SI
+	MOVL 8(R9), DI
+	MOVL 12(R9), R12
+	MOVL 16(R9), DX
+
+	// message scheduling pre-compute for the first block
+	MOVOU 0(R10), X0
+	PSHUFB X10, X0
+	MOVO X0, X1
+	MOVOU 0(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 0(SP)
+	MOVOU 16(R10), X0
+	PSHUFB X10, X0
+	MOVO X0, X8
+	MOVOU 0(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 16(SP)
+	MOVOU 32(R10), X0
+	PSHUFB X10, X0
+	MOVO X0, X7
+	MOVOU 0(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 32(SP)
+	MOVOU 48(R10), X0
+	PSHUFB X10, X0
+	MOVO X0, X6
+	MOVOU 0(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 48(SP)
+
+loop:
+	// K_BASE in BUFFER_PTR signals that the last block has been processed
+	CMPQ R10, R8
+	JEQ  end
+
+	// rounds 0 and 1
+	MOVO X8, X5
+	PALIGNR $8, X1, X5
+	MOVO X6, X0
+	PSRLO $4, X0
+	PXOR X7, X5
+	MOVL DI, AX
+	XORL R12, AX
+	ANDL SI, AX
+	XORL R12, AX
+	ADDL 0(SP), DX
+	ROLL $30, SI
+	MOVL CX, BX
+	ADDL 4(SP), R12
+	ROLL $5, BX
+	ADDL AX, DX
+	PXOR X1, X0
+	PXOR X0, X5
+	MOVO X5, X9
+	MOVO X5, X0
+	PSLLO $12, X9
+	ADDL DX, BX
+	MOVL BX, DX
+	ROLL $5, BX
+	ADDL BX, R12
+	MOVL SI, AX
+	XORL DI, AX
+	ANDL CX, AX
+	XORL DI, AX
+	ADDL AX, R12
+	ROLL $30, CX
+
+	// rounds 2 and 3
+	PSRLL $31, X5
+	PSLLL $1, X0
+	POR X5, X0
+	MOVO X9, X5
+	PSRLL $30, X9
+	PSLLL $2, X5
+	MOVL CX, AX
+	XORL SI, AX
+	ANDL DX, AX
+	XORL SI, AX
+	ADDL 8(SP), DI
+	ROLL $30, DX
+	MOVL R12, BX
+	ADDL 12(SP), SI
+	ROLL $5, BX
+	ADDL AX, DI
+	PXOR X5, X0
+	PXOR X9, X0
+	MOVO X0, X5
+	MOVOU 0(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 0(SP)
+	ADDL DI, BX
+	MOVL BX, DI
+	ROLL $5, BX
+	ADDL BX, SI
+	MOVL DX, AX
+	XORL CX, AX
+	ANDL R12, AX
+	XORL CX, AX
+	ADDL AX, SI
+	ROLL $30, R12
+
+	// rounds 4 and 5
+	MOVO X7, X4
+	PALIGNR $8, X8, X4
+	MOVO X5, X0
+	PSRLO $4, X0
+	PXOR X6, X4
+	MOVL R12, AX
+	XORL DX, AX
+	ANDL DI, AX
+	XORL DX, AX
+	ADDL 16(SP), CX
+	ROLL $30, DI
+	MOVL SI, BX
+	ADDL 20(SP), DX
+	ROLL $5, BX
+	ADDL AX, CX
+	PXOR X8, X0
+	PXOR X0, X4
+	MOVO X4, X9
+	MOVO X4, X0
+	PSLLO $12, X9
+	ADDL CX, BX
+	MOVL BX, CX
+	ROLL $5, BX
+	ADDL BX, DX
+	MOVL DI, AX
+	XORL R12, AX
+	ANDL SI, AX
+	XORL R12, AX
+	ADDL AX, DX
+	ROLL $30, SI
+
+	// rounds 6 and 7
+	PSRLL $31, X4
+	PSLLL $1, X0
+	POR X4, X0
+	MOVO X9, X4
+	PSRLL $30, X9
+	PSLLL $2, X4
+	MOVL SI, AX
+	XORL DI, AX
+	ANDL CX, AX
+	XORL DI, AX
+	ADDL 24(SP), R12
+	ROLL $30, CX
+	MOVL DX, BX
+	ADDL 28(SP), DI
+	ROLL $5, BX
+	ADDL AX, R12
+	PXOR X4, X0
+	PXOR X9, X0
+	MOVO X0, X4
+	MOVOU 16(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 16(SP)
+	ADDL R12, BX
+	MOVL BX, R12
+	ROLL $5, BX
+	ADDL BX, DI
+	MOVL CX, AX
+	XORL SI, AX
+	ANDL DX, AX
+	XORL SI, AX
+	ADDL AX, DI
+	ROLL $30, DX
+
+	// rounds 8 and 9
+	MOVO X6, X3
+	PALIGNR $8, X7, X3
+	MOVO X4, X0
+	PSRLO $4, X0
+	PXOR X5, X3
+	MOVL DX, AX
+	XORL CX, AX
+	ANDL R12, AX
+	XORL CX, AX
+	ADDL 32(SP), SI
+	ROLL $30, R12
+	MOVL DI, BX
+	ADDL 36(SP), CX
+	ROLL $5, BX
+	ADDL AX, SI
+	PXOR X7, X0
+	PXOR X0, X3
+	MOVO X3, X9
+	MOVO X3, X0
+	PSLLO $12, X9
+	ADDL SI, BX
+	MOVL BX, SI
+	ROLL $5, BX
+	ADDL BX, CX
+	MOVL R12, AX
+	XORL DX, AX
+	ANDL DI, AX
+	XORL DX, AX
+	ADDL AX, CX
+	ROLL $30, DI
+
+	// rounds 10 and 11
+	PSRLL $31, X3
+	PSLLL $1, X0
+	POR X3, X0
+	MOVO X9, X3
+	PSRLL $30, X9
+	PSLLL $2, X3
+	MOVL DI, AX
+	XORL R12, AX
+	ANDL SI, AX
+	XORL R12, AX
+	ADDL 40(SP), DX
+	ROLL $30, SI
+	MOVL CX, BX
+	ADDL 44(SP), R12
+	ROLL $5, BX
+	ADDL AX, DX
+	PXOR X3, X0
+	PXOR X9, X0
+	MOVO X0, X3
+	MOVOU 16(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 32(SP)
+	ADDL DX, BX
+	MOVL BX, DX
+	ROLL $5, BX
+	ADDL BX, R12
+	MOVL SI, AX
+	XORL DI, AX
+	ANDL CX, AX
+	XORL DI, AX
+	ADDL AX, R12
+	ROLL $30, CX
+
+	// rounds 12 and 13
+	MOVO X5, X2
+	PALIGNR $8, X6, X2
+	MOVO X3, X0
+	PSRLO $4, X0
+	PXOR X4, X2
+	MOVL CX, AX
+	XORL SI, AX
+	ANDL DX, AX
+	XORL SI, AX
+	ADDL 48(SP), DI
+	ROLL $30, DX
+	MOVL R12, BX
+	ADDL 52(SP), SI
+	ROLL $5, BX
+	ADDL AX, DI
+	PXOR X6, X0
+	PXOR X0, X2
+	MOVO X2, X9
+	MOVO X2, X0
+	PSLLO $12, X9
+	ADDL DI, BX
+	MOVL BX, DI
+	ROLL $5, BX
+	ADDL BX, SI
+	MOVL DX, AX
+	XORL CX, AX
+	ANDL R12, AX
+	XORL CX, AX
+	ADDL AX, SI
+	ROLL $30, R12
+
+	// rounds 14 and 15
+	PSRLL $31, X2
+	PSLLL $1, X0
+	POR X2, X0
+	MOVO X9, X2
+	PSRLL $30, X9
+	PSLLL $2, X2
+	MOVL R12, AX
+	XORL DX, AX
+	ANDL DI, AX
+	XORL DX, AX
+	ADDL 56(SP), CX
+	ROLL $30, DI
+	MOVL SI, BX
+	ADDL 60(SP), DX
+	ROLL $5, BX
+	ADDL AX, CX
+	PXOR X2, X0
+	PXOR X9, X0
+	MOVO X0, X2
+	MOVOU 16(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 48(SP)
+	ADDL CX, BX
+	MOVL BX, CX
+	ROLL $5, BX
+	ADDL BX, DX
+	MOVL DI, AX
+	XORL R12, AX
+	ANDL SI, AX
+	XORL R12, AX
+	ADDL AX, DX
+	ROLL $30, SI
+
+	// rounds 16 and 17
+	MOVO X2, X0
+	PXOR X8, X1
+	PALIGNR $8, X3, X0
+	MOVL SI, AX
+	XORL DI, AX
+	ANDL CX, AX
+	XORL DI, AX
+	ADDL 0(SP), R12
+	ROLL $30, CX
+	MOVL DX, BX
+	ADDL 4(SP), DI
+	ROLL $5, BX
+	ADDL AX, R12
+	PXOR X5, X1
+	PXOR X0, X1
+	MOVO X1, X0
+	ADDL R12, BX
+	MOVL BX, R12
+	ROLL $5, BX
+	ADDL BX, DI
+	MOVL CX, AX
+	XORL SI, AX
+	ANDL DX, AX
+	XORL SI, AX
+	ADDL AX, DI
+	ROLL $30, DX
+
+	// rounds 18 and 19
+	PSRLL $30, X1
+	PSLLL $2, X0
+	POR X1, X0
+	MOVL DX, AX
+	XORL CX, AX
+	ANDL R12, AX
+	XORL CX, AX
+	ADDL 8(SP), SI
+	ROLL $30, R12
+	MOVL DI, BX
+	ADDL 12(SP), CX
+	ROLL $5, BX
+	ADDL AX, SI
+	MOVO X0, X1
+	MOVOU 16(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 0(SP)
+	ADDL SI, BX
+	MOVL BX, SI
+	ROLL $5, BX
+	ADDL BX, CX
+	MOVL R12, AX
+	XORL DX, AX
+	ANDL DI, AX
+	XORL DX, AX
+	ADDL AX, CX
+	ROLL $30, DI
+
+	// rounds 20 and 21
+	MOVO X1, X0
+	PXOR X7, X8
+	PALIGNR $8, X2, X0
+	MOVL R12, AX
+	XORL DI, AX
+	XORL SI, AX
+	ADDL 16(SP), DX
+	ROLL $30, SI
+	MOVL CX, BX
+	ADDL 20(SP), R12
+	ROLL $5, BX
+	ADDL AX, DX
+	PXOR X4, X8
+	PXOR X0, X8
+	MOVO X8, X0
+	ADDL DX, BX
+	MOVL BX, DX
+	ROLL $5, BX
+	ADDL BX, R12
+	MOVL DI, AX
+	XORL SI, AX
+	XORL CX, AX
+	ADDL AX, R12
+	ROLL $30, CX
+
+	// rounds 22 and 23
+	PSRLL $30, X8
+	PSLLL $2, X0
+	POR X8, X0
+	MOVL SI, AX
+	XORL CX, AX
+	XORL DX, AX
+	ADDL 24(SP), DI
+	ROLL $30, DX
+	MOVL R12, BX
+	ADDL 28(SP), SI
+	ROLL $5, BX
+	ADDL AX, DI
+	MOVO X0, X8
+	MOVOU 16(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 16(SP)
+	ADDL DI, BX
+	MOVL BX, DI
+	ROLL $5, BX
+	ADDL BX, SI
+	MOVL CX, AX
+	XORL DX, AX
+	XORL R12, AX
+	ADDL AX, SI
+	ROLL $30, R12
+
+	// rounds 24 and 25
+	MOVO X8, X0
+	PXOR X6, X7
+	PALIGNR $8, X1, X0
+	MOVL DX, AX
+	XORL R12, AX
+	XORL DI, AX
+	ADDL 32(SP), CX
+	ROLL $30, DI
+	MOVL SI, BX
+	ADDL 36(SP), DX
+	ROLL $5, BX
+	ADDL AX, CX
+	PXOR X3, X7
+	PXOR X0, X7
+	MOVO X7, X0
+	ADDL CX, BX
+	MOVL BX, CX
+	ROLL $5, BX
+	ADDL BX, DX
+	MOVL R12, AX
+	XORL DI, AX
+	XORL SI, AX
+	ADDL AX, DX
+	ROLL $30, SI
+
+	// rounds 26 and 27
+	PSRLL $30, X7
+	PSLLL $2, X0
+	POR X7, X0
+	MOVL DI, AX
+	XORL SI, AX
+	XORL CX, AX
+	ADDL 40(SP), R12
+	ROLL $30, CX
+	MOVL DX, BX
+	ADDL 44(SP), DI
+	ROLL $5, BX
+	ADDL AX, R12
+	MOVO X0, X7
+	MOVOU 32(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 32(SP)
+	ADDL R12, BX
+	MOVL BX, R12
+	ROLL $5, BX
+	ADDL BX, DI
+	MOVL SI, AX
+	XORL CX, AX
+	XORL DX, AX
+	ADDL AX, DI
+	ROLL $30, DX
+
+	// rounds 28 and 29
+	MOVO X7, X0
+	PXOR X5, X6
+	PALIGNR $8, X8, X0
+	MOVL CX, AX
+	XORL DX, AX
+	XORL R12, AX
+	ADDL 48(SP), SI
+	ROLL $30, R12
+	MOVL DI, BX
+	ADDL 52(SP), CX
+	ROLL $5, BX
+	ADDL AX, SI
+	PXOR X2, X6
+	PXOR X0, X6
+	MOVO X6, X0
+	ADDL SI, BX
+	MOVL BX, SI
+	ROLL $5, BX
+	ADDL BX, CX
+	MOVL DX, AX
+	XORL R12, AX
+	XORL DI, AX
+	ADDL AX, CX
+	ROLL $30, DI
+
+	// rounds 30 and 31
+	PSRLL $30, X6
+	PSLLL $2, X0
+	POR X6, X0
+	MOVL R12, AX
+	XORL DI, AX
+	XORL SI, AX
+	ADDL 56(SP), DX
+	ROLL $30, SI
+	MOVL CX, BX
+	ADDL 60(SP), R12
+	ROLL $5, BX
+	ADDL AX, DX
+	MOVO X0, X6
+	MOVOU 32(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 48(SP)
+	ADDL DX, BX
+	MOVL BX, DX
+	ROLL $5, BX
+	ADDL BX, R12
+	MOVL DI, AX
+	XORL SI, AX
+	XORL CX, AX
+	ADDL AX, R12
+	ROLL $30, CX
+
+	// rounds 32 and 33
+	MOVO X6, X0
+	PXOR X4, X5
+	PALIGNR $8, X7, X0
+	MOVL SI, AX
+	XORL CX, AX
+	XORL DX, AX
+	ADDL 0(SP), DI
+	ROLL $30, DX
+	MOVL R12, BX
+	ADDL 4(SP), SI
+	ROLL $5, BX
+	ADDL AX, DI
+	PXOR X1, X5
+	PXOR X0, X5
+	MOVO X5, X0
+	ADDL DI, BX
+	MOVL BX, DI
+	ROLL $5, BX
+	ADDL BX, SI
+	MOVL CX, AX
+	XORL DX, AX
+	XORL R12, AX
+	ADDL AX, SI
+	ROLL $30, R12
+
+	// rounds 34 and 35
+	PSRLL $30, X5
+	PSLLL $2, X0
+	POR X5, X0
+	MOVL DX, AX
+	XORL R12, AX
+	XORL DI, AX
+	ADDL 8(SP), CX
+	ROLL $30, DI
+	MOVL SI, BX
+	ADDL 12(SP), DX
+	ROLL $5, BX
+	ADDL AX, CX
+	MOVO X0, X5
+	MOVOU 32(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 0(SP)
+	ADDL CX, BX
+	MOVL BX, CX
+	ROLL $5, BX
+	ADDL BX, DX
+	MOVL R12, AX
+	XORL DI, AX
+	XORL SI, AX
+	ADDL AX, DX
+	ROLL $30, SI
+
+	// rounds 36 and 37
+	MOVO X5, X0
+	PXOR X3, X4
+	PALIGNR $8, X6, X0
+	MOVL DI, AX
+	XORL SI, AX
+	XORL CX, AX
+	ADDL 16(SP), R12
+	ROLL $30, CX
+	MOVL DX, BX
+	ADDL 20(SP), DI
+	ROLL $5, BX
+	ADDL AX, R12
+	PXOR X8, X4
+	PXOR X0, X4
+	MOVO X4, X0
+	ADDL R12, BX
+	MOVL BX, R12
+	ROLL $5, BX
+	ADDL BX, DI
+	MOVL SI, AX
+	XORL CX, AX
+	XORL DX, AX
+	ADDL AX, DI
+	ROLL $30, DX
+
+	// rounds 38 and 39
+	PSRLL $30, X4
+	PSLLL $2, X0
+	POR X4, X0
+	MOVL CX, AX
+	XORL DX, AX
+	XORL R12, AX
+	ADDL 24(SP), SI
+	ROLL $30, R12
+	MOVL DI, BX
+	ADDL 28(SP), CX
+	ROLL $5, BX
+	ADDL AX, SI
+	MOVO X0, X4
+	MOVOU 32(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 16(SP)
+	ADDL SI, BX
+	MOVL BX, SI
+	ROLL $5, BX
+	ADDL BX, CX
+	MOVL DX, AX
+	XORL R12, AX
+	XORL DI, AX
+	ADDL AX, CX
+	ROLL $30, DI
+
+	// rounds 40 and 41
+	MOVO X4, X0
+	PXOR X2, X3
+	PALIGNR $8, X5, X0
+	MOVL DI, AX
+	MOVL SI, BX
+	ORL SI, AX
+	ANDL DI, BX
+	ANDL R12, AX
+	ORL BX, AX
+	ADDL 32(SP), DX
+	ROLL $30, SI
+	MOVL CX, BX
+	ADDL 36(SP), R12
+	ROLL $5, BX
+	ADDL AX, DX
+	PXOR X7, X3
+	PXOR X0, X3
+	MOVO X3, X0
+	ADDL DX, BX
+	MOVL BX, DX
+	ROLL $5, BX
+	ADDL BX, R12
+	MOVL SI, AX
+	MOVL CX, BX
+	ORL CX, AX
+	ANDL SI, BX
+	ANDL DI, AX
+	ORL BX, AX
+	ADDL AX, R12
+	ROLL $30, CX
+
+	// rounds 42 and 43
+	PSRLL $30, X3
+	PSLLL $2, X0
+	POR X3, X0
+	MOVL CX, AX
+	MOVL DX, BX
+	ORL DX, AX
+	ANDL CX, BX
+	ANDL SI, AX
+	ORL BX, AX
+	ADDL 40(SP), DI
+	ROLL $30, DX
+	MOVL R12, BX
+	ADDL 44(SP), SI
+	ROLL $5, BX
+	ADDL AX, DI
+	MOVO X0, X3
+	MOVOU 32(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 32(SP)
+	ADDL DI, BX
+	MOVL BX, DI
+	ROLL $5, BX
+	ADDL BX, SI
+	MOVL DX, AX
+	MOVL R12, BX
+	ORL R12, AX
+	ANDL DX, BX
+	ANDL CX, AX
+	ORL BX, AX
+	ADDL AX, SI
+	ROLL $30, R12
+
+	// rounds 44 and 45
+	MOVO X3, X0
+	PXOR X1, X2
+	PALIGNR $8, X4, X0
+	MOVL R12, AX
+	MOVL DI, BX
+	ORL DI, AX
+	ANDL R12, BX
+	ANDL DX, AX
+	ORL BX, AX
+	ADDL 48(SP), CX
+	ROLL $30, DI
+	MOVL SI, BX
+	ADDL 52(SP), DX
+	ROLL $5, BX
+	ADDL AX, CX
+	PXOR X6, X2
+	PXOR X0, X2
+	MOVO X2, X0
+	ADDL CX, BX
+	MOVL BX, CX
+	ROLL $5, BX
+	ADDL BX, DX
+	MOVL DI, AX
+	MOVL SI, BX
+	ORL SI, AX
+	ANDL DI, BX
+	ANDL R12, AX
+	ORL BX, AX
+	ADDL AX, DX
+	ROLL $30, SI
+
+	// rounds 46 and 47
+	PSRLL $30, X2
+	PSLLL $2, X0
+	POR X2, X0
+	MOVL SI, AX
+	MOVL CX, BX
+	ORL CX, AX
+	ANDL SI, BX
+	ANDL DI, AX
+	ORL BX, AX
+	ADDL 56(SP), R12
+	ROLL $30, CX
+	MOVL DX, BX
+	ADDL 60(SP), DI
+	ROLL $5, BX
+	ADDL AX, R12
+	MOVO X0, X2
+	MOVOU 48(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 48(SP)
+	ADDL R12, BX
+	MOVL BX, R12
+	ROLL $5, BX
+	ADDL BX, DI
+	MOVL CX, AX
+	MOVL DX, BX
+	ORL DX, AX
+	ANDL CX, BX
+	ANDL SI, AX
+	ORL BX, AX
+	ADDL AX, DI
+	ROLL $30, DX
+
+	// rounds 48 and 49
+	MOVO X2, X0
+	PXOR X8, X1
+	PALIGNR $8, X3, X0
+	MOVL DX, AX
+	MOVL R12, BX
+	ORL R12, AX
+	ANDL DX, BX
+	ANDL CX, AX
+	ORL BX, AX
+	ADDL 0(SP), SI
+	ROLL $30, R12
+	MOVL DI, BX
+	ADDL 4(SP), CX
+	ROLL $5, BX
+	ADDL AX, SI
+	PXOR X5, X1
+	PXOR X0, X1
+	MOVO X1, X0
+	ADDL SI, BX
+	MOVL BX, SI
+	ROLL $5, BX
+	ADDL BX, CX
+	MOVL R12, AX
+	MOVL DI, BX
+	ORL DI, AX
+	ANDL R12, BX
+	ANDL DX, AX
+	ORL BX, AX
+	ADDL AX, CX
+	ROLL $30, DI
+
+	// rounds 50 and 51
+	PSRLL $30, X1
+	PSLLL $2, X0
+	POR X1, X0
+	MOVL DI, AX
+	MOVL SI, BX
+	ORL SI, AX
+	ANDL DI, BX
+	ANDL R12, AX
+	ORL BX, AX
+	ADDL 8(SP), DX
+	ROLL $30, SI
+	MOVL CX, BX
+	ADDL 12(SP), R12
+	ROLL $5, BX
+	ADDL AX, DX
+	MOVO X0, X1
+	MOVOU 48(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 0(SP)
+	ADDL DX, BX
+	MOVL BX, DX
+	ROLL $5, BX
+	ADDL BX, R12
+	MOVL SI, AX
+	MOVL CX, BX
+	ORL CX, AX
+	ANDL SI, BX
+	ANDL DI, AX
+	ORL BX, AX
+	ADDL AX, R12
+	ROLL $30, CX
+
+	// rounds 52 and 53
+	MOVO X1, X0
+	PXOR X7, X8
+	PALIGNR $8, X2, X0
+	MOVL CX, AX
+	MOVL DX, BX
+	ORL DX, AX
+	ANDL CX, BX
+	ANDL SI, AX
+	ORL BX, AX
+	ADDL 16(SP), DI
+	ROLL $30, DX
+	MOVL R12, BX
+	ADDL 20(SP), SI
+	ROLL $5, BX
+	ADDL AX, DI
+	PXOR X4, X8
+	PXOR X0, X8
+	MOVO X8, X0
+	ADDL DI, BX
+	MOVL BX, DI
+	ROLL $5, BX
+	ADDL BX, SI
+	MOVL DX, AX
+	MOVL R12, BX
+	ORL R12, AX
+	ANDL DX, BX
+	ANDL CX, AX
+	ORL BX, AX
+	ADDL AX, SI
+	ROLL $30, R12
+
+	// rounds 54 and 55
+	PSRLL $30, X8
+	PSLLL $2, X0
+	POR X8, X0
+	MOVL R12, AX
+	MOVL DI, BX
+	ORL DI, AX
+	ANDL R12, BX
+	ANDL DX, AX
+	ORL BX, AX
+	ADDL 24(SP), CX
+	ROLL $30, DI
+	MOVL SI, BX
+	ADDL 28(SP), DX
+	ROLL $5, BX
+	ADDL AX, CX
+	MOVO X0, X8
+	MOVOU 48(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 16(SP)
+	ADDL CX, BX
+	MOVL BX, CX
+	ROLL $5, BX
+	ADDL BX, DX
+	MOVL DI, AX
+	MOVL SI, BX
+	ORL SI, AX
+	ANDL DI, BX
+	ANDL R12, AX
+	ORL BX, AX
+	ADDL AX, DX
+	ROLL $30, SI
+
+	// rounds 56 and 57
+	MOVO X8, X0
+	PXOR X6, X7
+	PALIGNR $8, X1, X0
+	MOVL SI, AX
+	MOVL CX, BX
+	ORL CX, AX
+	ANDL SI, BX
+	ANDL DI, AX
+	ORL BX, AX
+	ADDL 32(SP), R12
+	ROLL $30, CX
+	MOVL DX, BX
+	ADDL 36(SP), DI
+	ROLL $5, BX
+	ADDL AX, R12
+	PXOR X3, X7
+	PXOR X0, X7
+	MOVO X7, X0
+	ADDL R12, BX
+	MOVL BX, R12
+	ROLL $5, BX
+	ADDL BX, DI
+	MOVL CX, AX
+	MOVL DX, BX
+	ORL DX, AX
+	ANDL CX, BX
+	ANDL SI, AX
+	ORL BX, AX
+	ADDL AX, DI
+	ROLL $30, DX
+
+	// rounds 58 and 59
+	PSRLL $30, X7
+	PSLLL $2, X0
+	POR X7, X0
+	MOVL DX, AX
+	MOVL R12, BX
+	ORL R12, AX
+	ANDL DX, BX
+	ANDL CX, AX
+	ORL BX, AX
+	ADDL 40(SP), SI
+	ROLL $30, R12
+	MOVL DI, BX
+	ADDL 44(SP), CX
+	ROLL $5, BX
+	ADDL AX, SI
+	MOVO X0, X7
+	MOVOU 48(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 32(SP)
+	ADDL SI, BX
+	MOVL BX, SI
+	ROLL $5, BX
+	ADDL BX, CX
+	MOVL R12, AX
+	MOVL DI, BX
+	ORL DI, AX
+	ANDL R12, BX
+	ANDL DX, AX
+	ORL BX, AX
+	ADDL AX, CX
+	ROLL $30, DI
+
+	// move to the next block, or to K_BASE after the last one, so that
+	// the pre-compute of rounds 64-79 never reads past the input
+	ADDQ $64, R10
+	CMPQ R10, R11
+	CMOVQCC R8, R10
+
+	// rounds 60 and 61
+	MOVO X7, X0
+	PXOR X5, X6
+	PALIGNR $8, X8, X0
+	MOVL R12, AX
+	XORL DI, AX
+	XORL SI, AX
+	ADDL 48(SP), DX
+	ROLL $30, SI
+	MOVL CX, BX
+	ADDL 52(SP), R12
+	ROLL $5, BX
+	ADDL AX, DX
+	PXOR X2, X6
+	PXOR X0, X6
+	MOVO X6, X0
+	ADDL DX, BX
+	MOVL BX, DX
+	ROLL $5, BX
+	ADDL BX, R12
+	MOVL DI, AX
+	XORL SI, AX
+	XORL CX, AX
+	ADDL AX, R12
+	ROLL $30, CX
+
+	// rounds 62 and 63
+	PSRLL $30, X6
+	PSLLL $2, X0
+	POR X6, X0
+	MOVL SI, AX
+	XORL CX, AX
+	XORL DX, AX
+	ADDL 56(SP), DI
+	ROLL $30, DX
+	MOVL R12, BX
+	ADDL 60(SP), SI
+	ROLL $5, BX
+	ADDL AX, DI
+	MOVO X0, X6
+	MOVOU 48(R8), X11
+	PADDL X11, X0
+	MOVOU X0, 48(SP)
+	ADDL DI, BX
+	MOVL BX, DI
+	ROLL $5, BX
+	ADDL BX, SI
+	MOVL CX, AX
+	XORL DX, AX
+	XORL R12, AX
+	ADDL AX, SI
+	ROLL $30, R12
+
+	// rounds 64 and 65
+	MOVOU 0(R10), X0
+	MOVL DX, AX
+	XORL R12, AX
+	XORL DI, AX
+	ADDL 0(SP), CX
+	ROLL $30, DI
+	MOVL SI, BX
+	ADDL 4(SP), DX
+	ROLL $5, BX
+	ADDL AX, CX
+	PSHUFB X10, X0
+	MOVO X0, X1
+	ADDL CX, BX
+	MOVL BX, CX
+	ROLL $5, BX
+	ADDL BX, DX
+	MOVL R12, AX
+	XORL DI, AX
+	XORL SI, AX
+	ADDL AX, DX
+	ROLL $30, SI
+
+	// rounds 66 and 67
+	MOVOU 0(R8), X11
+	PADDL X11, X0
+	MOVL DI, AX
+	XORL SI, AX
+	XORL CX, AX
+	ADDL 8(SP), R12
+	ROLL $30, CX
+	MOVL DX, BX
+	ADDL 12(SP), DI
+	ROLL $5, BX
+	ADDL AX, R12
+	MOVOU X0, 0(SP)
+	ADDL R12, BX
+	MOVL BX, R12
+	ROLL $5, BX
+	ADDL BX, DI
+	MOVL SI, AX
+	XORL CX, AX
+	XORL DX, AX
+	ADDL AX, DI
+	ROLL $30, DX
+
+	// rounds 68 and 69
+	MOVOU 16(R10), X0
+	MOVL CX, AX
+	XORL DX, AX
+	XORL R12, AX
+	ADDL 16(SP), SI
+	ROLL $30, R12
+	MOVL DI, BX
+	ADDL 20(SP), CX
+	ROLL $5, BX
+	ADDL AX, SI
+	PSHUFB X10, X0
+	MOVO X0, X8
+	ADDL SI, BX
+	MOVL BX, SI
+	ROLL $5, BX
+	ADDL BX, CX
+	MOVL DX, AX
+	XORL R12, AX
+	XORL DI, AX
+	ADDL AX, CX
+	ROLL $30, DI
+
+	// rounds 70 and 71
+	MOVOU 0(R8), X11
+	PADDL X11, X0
+	MOVL R12, AX
+	XORL DI, AX
+	XORL SI, AX
+	ADDL 24(SP), DX
+	ROLL $30, SI
+	MOVL CX, BX
+	ADDL 28(SP), R12
+	ROLL $5, BX
+	ADDL AX, DX
+	MOVOU X0, 16(SP)
+	ADDL DX, BX
+	MOVL BX, DX
+	ROLL $5, BX
+	ADDL BX, R12
+	MOVL DI, AX
+	XORL SI, AX
+	XORL CX, AX
+	ADDL AX, R12
+	ROLL $30, CX
+
+	// rounds 72 and 73
+	MOVOU 32(R10), X0
+	MOVL SI, AX
+	XORL CX, AX
+	XORL DX, AX
+	ADDL 32(SP), DI
+	ROLL $30, DX
+	MOVL R12, BX
+	ADDL 36(SP), SI
+	ROLL $5, BX
+	ADDL AX, DI
+	PSHUFB X10, X0
+	MOVO X0, X7
+	ADDL DI, BX
+	MOVL BX, DI
+	ROLL $5, BX
+	ADDL BX, SI
+	MOVL CX, AX
+	XORL DX, AX
+	XORL R12, AX
+	ADDL AX, SI
+	ROLL $30, R12
+
+	// rounds 74 and 75
+	MOVOU 0(R8), X11
+	PADDL X11, X0
+	MOVL DX, AX
+	XORL R12, AX
+	XORL DI, AX
+	ADDL 40(SP), CX
+	ROLL $30, DI
+	MOVL SI, BX
+	ADDL 44(SP), DX
+	ROLL $5, BX
+	ADDL AX, CX
+	MOVOU X0, 32(SP)
+	ADDL CX, BX
+	MOVL BX, CX
+	ROLL $5, BX
+	ADDL BX, DX
+	MOVL R12, AX
+	XORL DI, AX
+	XORL SI, AX
+	ADDL AX, DX
+	ROLL $30, SI
+
+	// rounds 76 and 77
+	MOVOU 48(R10), X0
+	MOVL DI, AX
+	XORL SI, AX
+	XORL CX, AX
+	ADDL 48(SP), R12
+	ROLL $30, CX
+	MOVL DX, BX
+	ADDL 52(SP), DI
+	ROLL $5, BX
+	ADDL AX, R12
+	PSHUFB X10, X0
+	MOVO X0, X6
+	ADDL R12, BX
+	MOVL BX, R12
+	ROLL $5, BX
+	ADDL BX, DI
+	MOVL SI, AX
+	XORL CX, AX
+	XORL DX, AX
+	ADDL AX, DI
+	ROLL $30, DX
+
+	// rounds 78 and 79
+	MOVOU 0(R8), X11
+	PADDL X11, X0
+	MOVL CX, AX
+	XORL DX, AX
+	XORL R12, AX
+	ADDL 56(SP), SI
+	ROLL $30, R12
+	MOVL DI, BX
+	ADDL 60(SP), CX
+	ROLL $5, BX
+	ADDL AX, SI
+	MOVOU X0, 48(SP)
+	ADDL SI, BX
+	MOVL BX, SI
+	ROLL $5, BX
+	ADDL BX, CX
+	MOVL DX, AX
+	XORL R12, AX
+	XORL DI, AX
+	ADDL AX, CX
+	ROLL $30, DI
+
+	ADDL 0(R9), CX
+	MOVL CX, 0(R9)
+	ADDL 4(R9), SI
+	MOVL SI, 4(R9)
+	ADDL 8(R9), DI
+	MOVL DI, 8(R9)
+	ADDL 12(R9), R12
+	MOVL R12, 12(R9)
+	ADDL 16(R9), DX
+	MOVL DX, 16(R9)
+	JMP  loop
+
+end:
+	RET
+
+DATA K_XMM_AR<>+0(SB)/4, $0x5a827999
+DATA K_XMM_AR<>+4(SB)/4, $0x5a827999
+DATA K_XMM_AR<>+8(SB)/4, $0x5a827999
+DATA K_XMM_AR<>+12(SB)/4, $0x5a827999
+DATA K_XMM_AR<>+16(SB)/4, $0x6ed9eba1
+DATA K_XMM_AR<>+20(SB)/4, $0x6ed9eba1
+DATA K_XMM_AR<>+24(SB)/4, $0x6ed9eba1
+DATA K_XMM_AR<>+28(SB)/4, $0x6ed9eba1
+DATA K_XMM_AR<>+32(SB)/4, $0x8f1bbcdc
+DATA K_XMM_AR<>+36(SB)/4, $0x8f1bbcdc
+DATA K_XMM_AR<>+40(SB)/4, $0x8f1bbcdc
+DATA K_XMM_AR<>+44(SB)/4, $0x8f1bbcdc
+DATA K_XMM_AR<>+48(SB)/4, $0xca62c1d6
+DATA K_XMM_AR<>+52(SB)/4, $0xca62c1d6
+DATA K_XMM_AR<>+56(SB)/4, $0xca62c1d6
+DATA K_XMM_AR<>+60(SB)/4, $0xca62c1d6
+GLOBL K_XMM_AR<>(SB), RODATA, $64
+
+DATA BSWAP_SHUFB_CTL<>+0(SB)/4, $0x00010203
+DATA BSWAP_SHUFB_CTL<>+4(SB)/4, $0x04050607
+DATA BSWAP_SHUFB_CTL<>+8(SB)/4, $0x08090a0b
+DATA BSWAP_SHUFB_CTL<>+12(SB)/4, $0x0c0d0e0f
+GLOBL BSWAP_SHUFB_CTL<>(SB), RODATA, $16
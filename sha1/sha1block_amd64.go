@@ -0,0 +1,53 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sha1
+
+import "github.com/klauspost/cpuid"
+
+// Block transforms implemented in Plan 9 assembly, see
+// sha1block{SSE3,AVX2}_amd64.s.
+
+//go:noescape
+func blockSSE3(dig *digest, p []byte)
+
+//go:noescape
+func blockAVX2(dig *digest, p []byte)
+
+func block(dig *digest, p []byte) {
+	switch true {
+	case cpuid.CPU.AVX2() && cpuid.CPU.BMI1() && cpuid.CPU.BMI2() && len(p) >= 256:
+		// blockAVX2 processes two blocks per iteration and reads
+		// up to 192 bytes past the end of p while pre-computing the
+		// message schedule, keep the tail away from it.
+		safeLen := len(p) - 128
+		if safeLen%128 != 0 {
+			safeLen -= 64
+		}
+		blockAVX2(dig, p[:safeLen])
+		blockSSE3OrGeneric(dig, p[safeLen:])
+	default:
+		blockSSE3OrGeneric(dig, p)
+	}
+}
+
+func blockSSE3OrGeneric(dig *digest, p []byte) {
+	if cpuid.CPU.SSSE3() {
+		blockSSE3(dig, p)
+		return
+	}
+	blockGeneric(dig, p)
+}
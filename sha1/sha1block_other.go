@@ -1,4 +1,4 @@
-// +build !cgo arm
+// +build !amd64
 
 /*
  * Minio Cloud Storage, (C) 2016 Minio, Inc.
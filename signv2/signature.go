@@ -0,0 +1,183 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package signv2 implements server side verification of AWS signature
+// version 2 requests, both signed with an Authorization header and
+// presigned with query parameters.
+//
+// The string to sign is built by the same code the client uses to sign
+// requests, see minio.StringToSignV2.
+package signv2
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go-legacy"
+	"github.com/minio/minio-xl/pkg/probe"
+	"github.com/minio/minio-xl/pkg/sha1"
+)
+
+const (
+	// authHeaderPrefix is the scheme of signature V2 Authorization headers.
+	authHeaderPrefix = "AWS"
+
+	// maxSkew is the maximum allowed difference between the request
+	// date and the server clock.
+	maxSkew = 15 * time.Minute
+)
+
+var (
+	// ErrMissingAuthHeader - request has no Authorization header or query.
+	ErrMissingAuthHeader = errors.New("Missing signature V2 authorization")
+	// ErrInvalidAuthHeader - Authorization header is not "AWS AccessKeyId:Signature".
+	ErrInvalidAuthHeader = errors.New("Invalid signature V2 authorization header")
+	// ErrMissingDateHeader - neither Date nor X-Amz-Date header is present.
+	ErrMissingDateHeader = errors.New("Missing date header")
+	// ErrMalformedDate - the date header could not be parsed.
+	ErrMalformedDate = errors.New("Malformed date header")
+	// ErrRequestTimeTooSkewed - request date differs too much from the server clock.
+	ErrRequestTimeTooSkewed = errors.New("Request time too skewed")
+	// ErrMalformedExpires - Expires query parameter is not a number.
+	ErrMalformedExpires = errors.New("Malformed expires query parameter")
+	// ErrExpiredPresignRequest - presigned request has expired.
+	ErrExpiredPresignRequest = errors.New("Presigned request has expired")
+)
+
+// timeNow is replaced by tests.
+var timeNow = time.Now
+
+// Signature - local variables
+type Signature struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Request         *http.Request
+}
+
+// IsRequestSignatureV2 - is the request signed with a signature V2
+// Authorization header.
+func IsRequestSignatureV2(req *http.Request) bool {
+	return strings.HasPrefix(req.Header.Get("Authorization"), authHeaderPrefix+" ")
+}
+
+// IsRequestPresignedSignatureV2 - is the request a signature V2
+// presigned request.
+func IsRequestPresignedSignatureV2(req *http.Request) bool {
+	_, ok := req.URL.Query()["AWSAccessKeyId"]
+	return ok
+}
+
+// parseAuthHeader - splits "AWS AccessKeyId:Signature" into its fields.
+func parseAuthHeader(authHeaderValue string) (accessKeyID, signature string, err *probe.Error) {
+	if authHeaderValue == "" {
+		return "", "", probe.NewError(ErrMissingAuthHeader)
+	}
+	if !strings.HasPrefix(authHeaderValue, authHeaderPrefix+" ") {
+		return "", "", probe.NewError(ErrInvalidAuthHeader)
+	}
+	fields := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(authHeaderValue, authHeaderPrefix+" ")), ":", 2)
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		return "", "", probe.NewError(ErrInvalidAuthHeader)
+	}
+	return fields[0], fields[1], nil
+}
+
+// GetAccessKeyID - returns the access key id of a signature V2 request,
+// signed or presigned.
+func GetAccessKeyID(req *http.Request) (string, *probe.Error) {
+	if IsRequestPresignedSignatureV2(req) {
+		return req.URL.Query().Get("AWSAccessKeyId"), nil
+	}
+	accessKeyID, _, err := parseAuthHeader(req.Header.Get("Authorization"))
+	if err != nil {
+		return "", err.Trace()
+	}
+	return accessKeyID, nil
+}
+
+// getSignature - HMAC-SHA1 of stringToSign, base64 encoded.
+func (r Signature) getSignature(stringToSign string) string {
+	return base64.StdEncoding.EncodeToString(sha1.SumHMAC([]byte(r.SecretAccessKey), []byte(stringToSign)))
+}
+
+// checkDate - verify the request date is within maxSkew of the server clock.
+func (r Signature) checkDate() *probe.Error {
+	date := r.Request.Header.Get("X-Amz-Date")
+	if date == "" {
+		date = r.Request.Header.Get("Date")
+	}
+	if date == "" {
+		return probe.NewError(ErrMissingDateHeader)
+	}
+	t, e := http.ParseTime(date)
+	if e != nil {
+		// Amazon docs examples use a numeric zone.
+		if t, e = time.Parse(time.RFC1123Z, date); e != nil {
+			return probe.NewError(ErrMalformedDate)
+		}
+	}
+	if d := timeNow().Sub(t); d > maxSkew || d < -maxSkew {
+		return probe.NewError(ErrRequestTimeTooSkewed)
+	}
+	return nil
+}
+
+// compareSignature - constant time comparison of two signatures.
+func compareSignature(s1, s2 string) bool {
+	return subtle.ConstantTimeCompare([]byte(s1), []byte(s2)) == 1
+}
+
+// DoesSignatureMatch - verify the Authorization header signature.
+func (r Signature) DoesSignatureMatch() (bool, *probe.Error) {
+	accessKeyID, signature, err := parseAuthHeader(r.Request.Header.Get("Authorization"))
+	if err != nil {
+		return false, err.Trace()
+	}
+	if err := r.checkDate(); err != nil {
+		return false, err.Trace()
+	}
+	if accessKeyID != r.AccessKeyID {
+		return false, nil
+	}
+	stringToSign := minio.StringToSignV2(r.Request, r.Request.URL.Path)
+	return compareSignature(r.getSignature(stringToSign), signature), nil
+}
+
+// DoesPresignedSignatureMatch - verify the query string signature of a
+// presigned request.
+func (r Signature) DoesPresignedSignatureMatch() (bool, *probe.Error) {
+	query := r.Request.URL.Query()
+	if query.Get("AWSAccessKeyId") == "" || query.Get("Signature") == "" {
+		return false, probe.NewError(ErrMissingAuthHeader)
+	}
+	expires, e := strconv.ParseInt(query.Get("Expires"), 10, 64)
+	if e != nil {
+		return false, probe.NewError(ErrMalformedExpires)
+	}
+	if timeNow().Unix() > expires {
+		return false, probe.NewError(ErrExpiredPresignRequest)
+	}
+	if query.Get("AWSAccessKeyId") != r.AccessKeyID {
+		return false, nil
+	}
+	stringToSign := minio.PresignStringToSignV2(r.Request, expires, r.Request.URL.Path)
+	return compareSignature(r.getSignature(stringToSign), query.Get("Signature")), nil
+}
@@ -42,3 +42,39 @@ const (
 	// temporarily until this task completes. Anytime you consider using this priority level, please seek for approval.
 	CmdPrioritySuper
 )
+
+// commandNames are the names of the commands, as shown by List.
+var commandNames = map[Command]string{
+	CmdNOOP:           "noop",
+	CmdSignalEnd:      "end",
+	CmdSignalAbort:    "abort",
+	CmdSignalSuspend:  "suspend",
+	CmdSignalResume:   "resume",
+	CmdPriorityLow:    "low",
+	CmdPriorityMedium: "medium",
+	CmdPriorityHigh:   "high",
+	CmdPrioritySuper:  "super",
+}
+
+// String returns the name of the command.
+func (cmd Command) String() string {
+	if name, ok := commandNames[cmd]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// IsPriority returns true for the CmdPriority* commands.
+func (cmd Command) IsPriority() bool {
+	return cmd >= CmdPriorityLow && cmd <= CmdPrioritySuper
+}
+
+// ParsePriority returns the CmdPriority* command of the given name.
+func ParsePriority(name string) (Command, bool) {
+	for cmd, cmdName := range commandNames {
+		if cmd.IsPriority() && cmdName == name {
+			return cmd, true
+		}
+	}
+	return CmdNOOP, false
+}
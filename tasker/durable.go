@@ -0,0 +1,281 @@
+/*
+ * Minio Cloud Storage, (C) 2015 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio-xl/pkg/probe"
+	"github.com/minio/minio-xl/pkg/safe"
+)
+
+// recordVersion is the version of the task record format.
+const recordVersion = "1"
+
+// recordSuffix is the extension of task record files.
+const recordSuffix = ".task.json"
+
+var (
+	// ErrNotDurable - task or TaskCtl does not persist its state.
+	ErrNotDurable = errors.New("Task is not durable")
+	// ErrUnknownKind - no Runner is registered for the kind of task.
+	ErrUnknownKind = errors.New("Unknown task kind")
+)
+
+// Runner runs a durable task of one kind, it is started in its own go
+// routine.  spec is the description the task was started with and
+// checkpoint the last state saved with Handle.Checkpoint, nil for a new
+// task.  Like any task a Runner must comply with the TaskCtl commands
+// and Close its handle when it is done.
+type Runner func(h Handle, spec, checkpoint []byte)
+
+// taskRecord is the persistent state of a durable task.
+type taskRecord struct {
+	Version    string `json:"version"`
+	ID         TaskID `json:"id"`
+	Name       string `json:"name"`
+	Kind       string `json:"kind"`
+	Spec       []byte `json:"spec"`
+	Checkpoint []byte `json:"checkpoint,omitempty"`
+}
+
+// NewDurable creates a TaskCtl persisting durable tasks in dir.  Register
+// the Runner of every kind of task, then call Restore to re-create the
+// tasks left unfinished by a previous process.
+func NewDurable(name, dir string) (*TaskCtl, *probe.Error) {
+	if e := os.MkdirAll(dir, 0700); e != nil {
+		return nil, probe.NewError(e)
+	}
+	// No one else writes to dir, every temporary file is a leftover
+	// of a crash.
+	if e := safe.Sweep(dir, 0); e != nil {
+		return nil, probe.NewError(e)
+	}
+	tc := New(name)
+	tc.dir = dir
+	return tc, nil
+}
+
+// Register sets the Runner for durable tasks of kind.
+func (tc *TaskCtl) Register(kind string, run Runner) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	tc.runners[kind] = run
+}
+
+// recordPath returns the name of the record file of task id.
+func (tc *TaskCtl) recordPath(id TaskID) string {
+	return filepath.Join(tc.dir, strconv.FormatUint(uint64(id), 10)+recordSuffix)
+}
+
+// save writes the record of a durable task.
+func (tc *TaskCtl) save(t *task) *probe.Error {
+	t.mutex.Lock()
+	record := taskRecord{
+		Version:    recordVersion,
+		ID:         t.id,
+		Name:       t.name,
+		Kind:       t.kind,
+		Spec:       t.spec,
+		Checkpoint: t.checkpoint,
+	}
+	t.mutex.Unlock()
+
+	data, e := json.Marshal(record)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	file, e := safe.CreateFile(tc.recordPath(t.id))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if _, e = file.Write(data); e != nil {
+		file.Abort()
+		return probe.NewError(e)
+	}
+	if e = file.Close(); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// forget removes the record of a durable task which has finished or was
+// aborted.  Tasks interrupted by Shutdown or by the cancellation of their
+// parent context keep their record, they are re-created by Restore.
+func (tc *TaskCtl) forget(t *task, finished bool) {
+	tc.mutex.Lock()
+	shutdown := tc.shutdown
+	tc.mutex.Unlock()
+	if shutdown || !finished {
+		return
+	}
+
+	t.saveMutex.Lock()
+	defer t.saveMutex.Unlock()
+	os.Remove(tc.recordPath(t.id))
+}
+
+// startTask registers a durable task and starts its Runner.  The record
+// is saved without holding tc.mutex, the task is only listed once it is
+// durable.
+func (tc *TaskCtl) startTask(ctx context.Context, record taskRecord) (TaskID, *probe.Error) {
+	tc.mutex.Lock()
+	if tc.shutdown {
+		tc.mutex.Unlock()
+		return 0, probe.NewError(ErrShutdown)
+	}
+	run, ok := tc.runners[record.Kind]
+	if !ok {
+		tc.mutex.Unlock()
+		return 0, probe.NewError(ErrUnknownKind).Trace(record.Kind)
+	}
+	isNew := record.ID == 0
+	if isNew {
+		tc.nextID++
+		record.ID = tc.nextID
+	} else if record.ID > tc.nextID {
+		tc.nextID = record.ID
+	}
+	tc.mutex.Unlock()
+
+	tsk := newTask(ctx, tc, record.ID, record.Name)
+	tsk.kind = record.Kind
+	tsk.spec = record.Spec
+	tsk.checkpoint = record.Checkpoint
+	if err := tc.save(tsk); err != nil {
+		tsk.cancel()
+		return 0, err.Trace(record.Name)
+	}
+
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	if tc.shutdown {
+		// Shut down while saving, a new task must not come back on
+		// Restore, a restored one keeps its record.
+		tsk.cancel()
+		if isNew {
+			os.Remove(tc.recordPath(record.ID))
+		}
+		return 0, probe.NewError(ErrShutdown)
+	}
+	tsk.this = tc.tasks.PushBack(tsk)
+
+	go run(tsk.getHandle(), record.Spec, record.Checkpoint)
+	return record.ID, nil
+}
+
+// StartTask persists a new durable task of kind and starts its Runner
+// with spec.  The task is re-created by Restore until it ends by itself
+// or is aborted.
+func (tc *TaskCtl) StartTask(ctx context.Context, name, kind string, spec []byte) (TaskID, *probe.Error) {
+	if tc.dir == "" {
+		return 0, probe.NewError(ErrNotDurable)
+	}
+	id, err := tc.startTask(ctx, taskRecord{Name: name, Kind: kind, Spec: spec})
+	if err != nil {
+		return 0, err.Trace(name, kind)
+	}
+	return id, nil
+}
+
+// RestoreError maps the names of the task records Restore could not
+// restore to the reason, all other records were restored.
+type RestoreError map[string]error
+
+func (e RestoreError) Error() string {
+	var names []string
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var msgs []string
+	for _, name := range names {
+		msgs = append(msgs, name+": "+e[name].Error())
+	}
+	return "Unable to restore tasks: " + strings.Join(msgs, ", ")
+}
+
+// Restore re-creates the durable tasks left unfinished by a previous
+// process and starts their Runners with the last checkpoint.  It must be
+// called once, after all kinds of tasks are registered.  Records which
+// cannot be restored are reported together in a RestoreError, they do
+// not keep the others from being restored.
+func (tc *TaskCtl) Restore(ctx context.Context) *probe.Error {
+	if tc.dir == "" {
+		return probe.NewError(ErrNotDurable)
+	}
+	entries, e := ioutil.ReadDir(tc.dir)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	failed := make(RestoreError)
+	var records []taskRecord
+	names := make(map[TaskID]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), recordSuffix) {
+			continue
+		}
+		data, e := ioutil.ReadFile(filepath.Join(tc.dir, entry.Name()))
+		if e != nil {
+			failed[entry.Name()] = e
+			continue
+		}
+		var record taskRecord
+		if e = json.Unmarshal(data, &record); e != nil {
+			failed[entry.Name()] = e
+			continue
+		}
+		if record.Version != recordVersion {
+			failed[entry.Name()] = errors.New("Unsupported task record version " + record.Version)
+			continue
+		}
+		if record.ID == 0 {
+			failed[entry.Name()] = errors.New("Task record without id")
+			continue
+		}
+		records = append(records, record)
+		names[record.ID] = entry.Name()
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+
+	// Reserve the ids of all records before any task gets a new one.
+	tc.mutex.Lock()
+	for _, record := range records {
+		if record.ID > tc.nextID {
+			tc.nextID = record.ID
+		}
+	}
+	tc.mutex.Unlock()
+
+	for _, record := range records {
+		if _, err := tc.startTask(ctx, record); err != nil {
+			failed[names[record.ID]] = err.ToGoError()
+		}
+	}
+	if len(failed) > 0 {
+		return probe.NewError(failed)
+	}
+	return nil
+}
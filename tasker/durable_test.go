@@ -0,0 +1,221 @@
+/*
+ * Quick - Quick key value store for config files and persistent state files
+ *
+ * Minio Client (C) 2015 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package tasker_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio-xl/pkg/tasker"
+
+	. "gopkg.in/check.v1"
+)
+
+// counter is a durable task counting up to its spec, one step at a time.
+type counter struct {
+	steps    chan struct{}
+	progress chan int
+	started  chan int // the checkpoint each run starts from
+}
+
+func newCounter() *counter {
+	return &counter{
+		steps:    make(chan struct{}),
+		progress: make(chan int),
+		started:  make(chan int, 10),
+	}
+}
+
+func (cnt *counter) run(h tasker.Handle, spec, checkpoint []byte) {
+	defer h.Close()
+	target, _ := strconv.Atoi(string(spec))
+	n := 0
+	if checkpoint != nil {
+		n, _ = strconv.Atoi(string(checkpoint))
+	}
+	cnt.started <- n
+	for n < target {
+		select {
+		case cmd := <-h.Listen():
+			h.StatusDone()
+			if cmd == tasker.CmdSignalAbort {
+				return
+			}
+		case <-h.Context().Done():
+			return
+		case <-cnt.steps:
+			n++
+			if err := h.Checkpoint([]byte(strconv.Itoa(n))); err != nil {
+				panic(err)
+			}
+			cnt.progress <- n
+		}
+	}
+}
+
+func (cnt *counter) step(c *C, n int) {
+	for i := 0; i < n; i++ {
+		cnt.steps <- struct{}{}
+		<-cnt.progress
+	}
+}
+
+func waitEmpty(tc *tasker.TaskCtl) {
+	for len(tc.List()) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func records(c *C, dir string) []string {
+	entries, err := ioutil.ReadDir(dir)
+	c.Assert(err, IsNil)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func (s *MySuite) TestDurableRestore(c *C) {
+	dir, e := ioutil.TempDir("", "tasker-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(dir)
+
+	cnt := newCounter()
+	tc, err := tasker.NewDurable("Test Tasks", dir)
+	c.Assert(err, IsNil)
+	tc.Register("count", cnt.run)
+	c.Assert(tc.Restore(context.Background()), IsNil)
+
+	_, err = tc.StartTask(context.Background(), "count", "unknown", nil)
+	c.Assert(err, NotNil)
+	c.Assert(err.ToGoError(), Equals, tasker.ErrUnknownKind)
+
+	id, err := tc.StartTask(context.Background(), "count to 5", "count", []byte("5"))
+	c.Assert(err, IsNil)
+	c.Assert(<-cnt.started, Equals, 0)
+	cnt.step(c, 3)
+
+	// Shutdown keeps the record of the unfinished task.
+	c.Assert(tc.Shutdown(timeout), IsNil)
+	c.Assert(records(c, dir), HasLen, 1)
+
+	// A leftover temporary file of a crash is swept.
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "$tmpfile.crashed"), nil, 0600), IsNil)
+
+	tc, err = tasker.NewDurable("Test Tasks", dir)
+	c.Assert(err, IsNil)
+	tc.Register("count", cnt.run)
+	c.Assert(tc.Restore(context.Background()), IsNil)
+	c.Assert(<-cnt.started, Equals, 3)
+
+	infos := tc.List()
+	c.Assert(infos, HasLen, 1)
+	c.Assert(infos[0].ID, Equals, id)
+	c.Assert(infos[0].Name, Equals, "count to 5")
+
+	// New tasks do not reuse the ids of restored ones.
+	id2, err := tc.StartTask(context.Background(), "count to 1", "count", []byte("1"))
+	c.Assert(err, IsNil)
+	c.Assert(id2 > id, Equals, true)
+	c.Assert(<-cnt.started, Equals, 0)
+	cnt.step(c, 3)
+
+	// Finished tasks forget their records.
+	waitEmpty(tc)
+	c.Assert(records(c, dir), HasLen, 0)
+}
+
+func (s *MySuite) TestDurableRestoreErrors(c *C) {
+	dir, e := ioutil.TempDir("", "tasker-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(dir)
+
+	cnt := newCounter()
+	tc, err := tasker.NewDurable("Test Tasks", dir)
+	c.Assert(err, IsNil)
+	tc.Register("count", cnt.run)
+	tc.Register("other", cnt.run)
+	_, err = tc.StartTask(context.Background(), "other", "other", []byte("5"))
+	c.Assert(err, IsNil)
+	<-cnt.started
+	id, err := tc.StartTask(context.Background(), "count to 5", "count", []byte("5"))
+	c.Assert(err, IsNil)
+	<-cnt.started
+	c.Assert(tc.Shutdown(timeout), IsNil)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "9.task.json"), []byte("{"), 0600), IsNil)
+
+	// The bad records are reported, the good one is restored anyway.
+	tc, err = tasker.NewDurable("Test Tasks", dir)
+	c.Assert(err, IsNil)
+	tc.Register("count", cnt.run)
+	err = tc.Restore(context.Background())
+	c.Assert(err, NotNil)
+	failed, ok := err.ToGoError().(tasker.RestoreError)
+	c.Assert(ok, Equals, true)
+	c.Assert(failed, HasLen, 2)
+	c.Assert(failed["1.task.json"], Equals, tasker.ErrUnknownKind)
+	c.Assert(failed["9.task.json"], NotNil)
+	c.Assert(<-cnt.started, Equals, 0)
+	infos := tc.List()
+	c.Assert(infos, HasLen, 1)
+	c.Assert(infos[0].ID, Equals, id)
+	c.Assert(tc.Shutdown(timeout), IsNil)
+}
+
+func (s *MySuite) TestDurableAbort(c *C) {
+	dir, e := ioutil.TempDir("", "tasker-")
+	c.Assert(e, IsNil)
+	defer os.RemoveAll(dir)
+
+	cnt := newCounter()
+	tc, err := tasker.NewDurable("Test Tasks", dir)
+	c.Assert(err, IsNil)
+	tc.Register("count", cnt.run)
+
+	id, err := tc.StartTask(context.Background(), "count to 5", "count", []byte("5"))
+	c.Assert(err, IsNil)
+	<-cnt.started
+	cnt.step(c, 1)
+
+	// Aborted tasks are not restored.
+	c.Assert(tc.Abort(id, timeout), IsNil)
+	c.Assert(records(c, dir), HasLen, 0)
+
+	// Cancelling the parent context interrupts the task, it is restored.
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = tc.StartTask(ctx, "count to 5", "count", []byte("5"))
+	c.Assert(err, IsNil)
+	<-cnt.started
+	cnt.step(c, 2)
+	cancel()
+	waitEmpty(tc)
+	c.Assert(records(c, dir), HasLen, 1)
+
+	// Non durable tasks cannot checkpoint.
+	h, err := tc.NewTask(context.Background(), "plain")
+	c.Assert(err, IsNil)
+	err = h.Checkpoint(nil)
+	c.Assert(err, NotNil)
+	c.Assert(err.ToGoError(), Equals, tasker.ErrNotDurable)
+	h.Close()
+}
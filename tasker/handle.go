@@ -16,41 +16,99 @@
 
 package tasker
 
-import "github.com/minio/minio/pkg/probe"
+import (
+	"context"
+
+	"github.com/minio/minio-xl/pkg/probe"
+)
 
 // Handle as the name suggests is a handle (self reference) to its
 // own task structure. Task has limited privileges over itself. Only the
 // task controller (TaskCtl) can manage the task by sending commands to
 // the task over channels.
+//
+// A task is expected to select on Listen() and Context().Done() in its
+// work loop, acknowledge every command with one of the Status methods,
+// and call Close() when it ends.  A suspended task keeps listening for
+// CmdSignalResume or CmdSignalAbort without doing any work.
 type Handle struct {
-	this     taskRef
-	cmdCh    <-chan Command // Channel to receive commands from TaskCtl.
-	statusCh chan<- status  // Channel to send completion status and error (if any) to TaskCtl.
-	closeCh  chan<- taskRef // Channel to notify the TaskCtl about ending this task.
+	t *task
+}
+
+// ID returns the unique id of the task.
+func (h Handle) ID() TaskID {
+	return h.t.id
+}
+
+// Context returns the context of the task, it is cancelled when the task
+// is aborted.
+func (h Handle) Context() context.Context {
+	return h.t.ctx
 }
 
 // Listen returns a channel to receive commands.
-func (t Handle) Listen() <-chan Command {
-	return t.cmdCh
+func (h Handle) Listen() <-chan Command {
+	return h.t.cmdCh
+}
+
+// sendStatus acknowledges the last command, it never blocks.
+func (h Handle) sendStatus(st status) {
+	select {
+	case h.t.statusCh <- st:
+	default:
+	}
 }
 
 // StatusDone acknowledges successful completion of a command.
-func (t Handle) StatusDone() {
-	t.statusCh <- status{code: statusDone, err: nil}
+func (h Handle) StatusDone() {
+	h.sendStatus(status{code: statusDone, err: nil})
 }
 
 // StatusBusy rejects a command with busy status.
-func (t Handle) StatusBusy() {
-	t.statusCh <- status{code: statusBusy, err: nil}
+func (h Handle) StatusBusy() {
+	h.sendStatus(status{code: statusBusy, err: nil})
 }
 
 // StatusFail returns failure status.
-func (t Handle) StatusFail(err *probe.Error) {
-	t.statusCh <- status{code: statusFail, err: err}
+func (h Handle) StatusFail(err *probe.Error) {
+	h.sendStatus(status{code: statusFail, err: err})
+}
+
+// Progress reports that done out of total units of work are complete.
+func (h Handle) Progress(done, total int64) {
+	h.t.mutex.Lock()
+	defer h.t.mutex.Unlock()
+	h.t.done = done
+	h.t.total = total
+}
+
+// Checkpoint saves the state of a durable task, a restarted process
+// passes the last saved state to the Runner of the task.  It returns once
+// the state is on stable storage.
+func (h Handle) Checkpoint(state []byte) *probe.Error {
+	t := h.t
+	if t.kind == "" {
+		return probe.NewError(ErrNotDurable).Trace(t.name)
+	}
+	t.saveMutex.Lock()
+	defer t.saveMutex.Unlock()
+
+	t.mutex.Lock()
+	if t.closed {
+		t.mutex.Unlock()
+		return probe.NewError(ErrTaskClosed).Trace(t.name)
+	}
+	t.checkpoint = append([]byte(nil), state...)
+	t.mutex.Unlock()
+
+	if err := t.ctl.save(t); err != nil {
+		return err.Trace(t.name)
+	}
+	return nil
 }
 
 // Close notifies the TaskCtl about the end of this Task. Owner of the
 // task must invoke Close() when it is done performing its job.
-func (t Handle) Close() {
-	t.closeCh <- t.this
+func (h Handle) Close() {
+	h.t.ctl.remove(h.t)
 }
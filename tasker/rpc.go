@@ -0,0 +1,169 @@
+/*
+ * Minio Cloud Storage, (C) 2015 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tasker
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio-xl/pkg/probe"
+)
+
+// DefaultRPCTimeout bounds the commands of the admin service when the
+// request does not set a timeout.
+const DefaultRPCTimeout = 10 * time.Second
+
+// ErrInvalidArgument - malformed admin request.
+var ErrInvalidArgument = errors.New("Invalid argument")
+
+//// RPC params
+
+// RPCListArgs list params
+type RPCListArgs struct{}
+
+// RPCTaskArgs params of commands to a single task
+type RPCTaskArgs struct {
+	ID      TaskID `json:"id"`
+	Timeout string `json:"timeout"` // Go duration, like "5s", optional
+}
+
+// RPCPriorityArgs params of SetPriority
+type RPCPriorityArgs struct {
+	ID       TaskID `json:"id"`
+	Priority string `json:"priority"` // "low", "medium", "high" or "super"
+	Timeout  string `json:"timeout"`
+}
+
+//// RPC replies
+
+// RPCTaskRep description of a task
+type RPCTaskRep struct {
+	ID        TaskID `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Priority  string `json:"priority"`
+	Done      int64  `json:"done"`
+	Total     int64  `json:"total"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// RPCListRep collection of task replies
+type RPCListRep struct {
+	Tasks []RPCTaskRep `json:"tasks"`
+}
+
+// RPCService is the admin service of a TaskCtl, its methods follow the
+// conventions of github.com/gorilla/rpc so that it can be registered on
+// a JSON-RPC server:
+//
+//	s.RegisterService(tasker.NewRPCService(tc), "Tasker")
+type RPCService struct {
+	tc *TaskCtl
+}
+
+// NewRPCService returns the admin service of tc.
+func NewRPCService(tc *TaskCtl) *RPCService {
+	return &RPCService{tc: tc}
+}
+
+// newTaskRep converts a task description to its reply.
+func newTaskRep(info TaskInfo) RPCTaskRep {
+	rep := RPCTaskRep{
+		ID:       info.ID,
+		Name:     info.Name,
+		State:    info.State.String(),
+		Priority: info.Priority.String(),
+		Done:     info.Done,
+		Total:    info.Total,
+	}
+	if info.LastError != nil {
+		rep.LastError = info.LastError.ToGoError().Error()
+	}
+	return rep
+}
+
+// parseTimeout returns the timeout of a request.
+func parseTimeout(timeout string) (time.Duration, *probe.Error) {
+	if timeout == "" {
+		return DefaultRPCTimeout, nil
+	}
+	d, e := time.ParseDuration(timeout)
+	if e != nil || d <= 0 {
+		return 0, probe.NewError(ErrInvalidArgument).Trace(timeout)
+	}
+	return d, nil
+}
+
+// control runs fn on the task of args and replies with its description.
+func (s *RPCService) control(args *RPCTaskArgs, reply *RPCTaskRep, fn func(TaskID, time.Duration) *probe.Error) error {
+	timeout, err := parseTimeout(args.Timeout)
+	if err != nil {
+		return probe.WrapError(err)
+	}
+	info, err := s.tc.Info(args.ID)
+	if err != nil {
+		return probe.WrapError(err)
+	}
+	if err = fn(args.ID, timeout); err != nil {
+		return probe.WrapError(err)
+	}
+	// Aborted tasks are gone, reply with their last description.
+	if latest, err := s.tc.Info(args.ID); err == nil {
+		info = latest
+	} else {
+		info.State = StateAborting
+	}
+	*reply = newTaskRep(info)
+	return nil
+}
+
+// List lists all tasks
+func (s *RPCService) List(r *http.Request, args *RPCListArgs, reply *RPCListRep) error {
+	reply.Tasks = []RPCTaskRep{}
+	for _, info := range s.tc.List() {
+		reply.Tasks = append(reply.Tasks, newTaskRep(info))
+	}
+	return nil
+}
+
+// Suspend suspends a task
+func (s *RPCService) Suspend(r *http.Request, args *RPCTaskArgs, reply *RPCTaskRep) error {
+	return s.control(args, reply, s.tc.Suspend)
+}
+
+// Resume resumes a suspended task
+func (s *RPCService) Resume(r *http.Request, args *RPCTaskArgs, reply *RPCTaskRep) error {
+	return s.control(args, reply, s.tc.Resume)
+}
+
+// Abort aborts a task
+func (s *RPCService) Abort(r *http.Request, args *RPCTaskArgs, reply *RPCTaskRep) error {
+	return s.control(args, reply, s.tc.Abort)
+}
+
+// SetPriority changes the priority of a task
+func (s *RPCService) SetPriority(r *http.Request, args *RPCPriorityArgs, reply *RPCTaskRep) error {
+	priority, ok := ParsePriority(args.Priority)
+	if !ok {
+		return probe.WrapError(probe.NewError(ErrInvalidPriority).Trace(args.Priority))
+	}
+	taskArgs := &RPCTaskArgs{ID: args.ID, Timeout: args.Timeout}
+	return s.control(taskArgs, reply, func(id TaskID, timeout time.Duration) *probe.Error {
+		return s.tc.SetPriority(id, priority, timeout)
+	})
+}
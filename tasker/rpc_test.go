@@ -0,0 +1,103 @@
+/*
+ * Quick - Quick key value store for config files and persistent state files
+ *
+ * Minio Client (C) 2015 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package tasker_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	jsonrpc "github.com/gorilla/rpc/v2"
+	"github.com/gorilla/rpc/v2/json"
+	"github.com/minio/minio-xl/pkg/tasker"
+
+	. "gopkg.in/check.v1"
+)
+
+func newRPCServer(c *C, tc *tasker.TaskCtl) *httptest.Server {
+	s := jsonrpc.NewServer()
+	s.RegisterCodec(json.NewCodec(), "application/json")
+	c.Assert(s.RegisterService(tasker.NewRPCService(tc), "Tasker"), IsNil)
+	return httptest.NewServer(s)
+}
+
+func call(c *C, url, method string, args, reply interface{}) error {
+	body, err := json.EncodeClientRequest(method, args)
+	c.Assert(err, IsNil)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	return json.DecodeClientResponse(resp.Body, reply)
+}
+
+func (s *MySuite) TestRPC(c *C) {
+	tc := tasker.New("Test Tasks")
+	id1, _ := startWorker(c, tc, "scrubber")
+	id2, w2 := startWorker(c, tc, "healer")
+	server := newRPCServer(c, tc)
+	defer server.Close()
+
+	var list tasker.RPCListRep
+	c.Assert(call(c, server.URL, "Tasker.List", tasker.RPCListArgs{}, &list), IsNil)
+	c.Assert(list.Tasks, HasLen, 2)
+	c.Assert(list.Tasks[0].ID, Equals, id1)
+	c.Assert(list.Tasks[0].Name, Equals, "scrubber")
+	c.Assert(list.Tasks[0].State, Equals, "running")
+	c.Assert(list.Tasks[0].Priority, Equals, "medium")
+
+	var rep tasker.RPCTaskRep
+	c.Assert(call(c, server.URL, "Tasker.Suspend", tasker.RPCTaskArgs{ID: id1, Timeout: "5s"}, &rep), IsNil)
+	c.Assert(rep.State, Equals, "suspended")
+	c.Assert(call(c, server.URL, "Tasker.Resume", tasker.RPCTaskArgs{ID: id1}, &rep), IsNil)
+	c.Assert(rep.State, Equals, "running")
+
+	c.Assert(call(c, server.URL, "Tasker.SetPriority", tasker.RPCPriorityArgs{ID: id2, Priority: "high"}, &rep), IsNil)
+	c.Assert(rep.ID, Equals, id2)
+	c.Assert(rep.Priority, Equals, "high")
+
+	c.Assert(call(c, server.URL, "Tasker.Abort", tasker.RPCTaskArgs{ID: id2}, &rep), IsNil)
+	c.Assert(rep.State, Equals, "aborting")
+	<-w2.done
+	c.Assert(call(c, server.URL, "Tasker.List", tasker.RPCListArgs{}, &list), IsNil)
+	c.Assert(list.Tasks, HasLen, 1)
+
+	// Errors are returned to the caller.
+	c.Assert(call(c, server.URL, "Tasker.Suspend", tasker.RPCTaskArgs{ID: id2}, &rep), NotNil)
+	c.Assert(call(c, server.URL, "Tasker.Suspend", tasker.RPCTaskArgs{ID: id1, Timeout: "soon"}, &rep), NotNil)
+	c.Assert(call(c, server.URL, "Tasker.SetPriority", tasker.RPCPriorityArgs{ID: id1, Priority: "abort"}, &rep), NotNil)
+
+	c.Assert(tc.Shutdown(timeout), IsNil)
+}
+
+func (s *MySuite) TestRPCLastError(c *C) {
+	tc := tasker.New("Test Tasks")
+	h, err := tc.NewTask(context.Background(), "stuck")
+	c.Assert(err, IsNil)
+	server := newRPCServer(c, tc)
+	defer server.Close()
+
+	var rep tasker.RPCTaskRep
+	c.Assert(call(c, server.URL, "Tasker.Suspend", tasker.RPCTaskArgs{ID: h.ID(), Timeout: "10ms"}, &rep), NotNil)
+
+	var list tasker.RPCListRep
+	c.Assert(call(c, server.URL, "Tasker.List", tasker.RPCListArgs{}, &list), IsNil)
+	c.Assert(list.Tasks, HasLen, 1)
+	c.Assert(list.Tasks[0].LastError, Equals, tasker.ErrTimeout.Error())
+	h.Close()
+}
@@ -18,7 +18,11 @@ package tasker
 
 import (
 	"container/list"
+	"context"
 	"sync"
+	"time"
+
+	"github.com/minio/minio-xl/pkg/probe"
 )
 
 // NOTE: Task is a private entity. It is created and managed by TaskCtl
@@ -29,75 +33,202 @@ import (
 // named "this".
 type taskRef *list.Element
 
+// TaskID uniquely identifies a task within its TaskCtl.
+type TaskID uint64
+
+// State is the life cycle state of a task.
+type State uint8
+
+// Enumerate the task states.
+const (
+	// StateRunning is the state of new and resumed tasks.
+	StateRunning State = iota
+	// StateSuspended tasks acknowledged CmdSignalSuspend.
+	StateSuspended
+	// StateAborting tasks were asked to abort and did not close yet.
+	StateAborting
+)
+
+// String returns the name of the state.
+func (s State) String() string {
+	switch s {
+	case StateRunning:
+		return "running"
+	case StateSuspended:
+		return "suspended"
+	case StateAborting:
+		return "aborting"
+	}
+	return "unknown"
+}
+
+// TaskInfo describes a task, see TaskCtl.List.
+type TaskInfo struct {
+	ID       TaskID
+	Name     string
+	State    State
+	Priority Command
+	Done     int64 // Progress reported by the task, in units of its choice.
+	Total    int64
+	// LastError is the last command failure or error reported by the
+	// task with StatusFail, nil if there was none.
+	LastError *probe.Error
+}
+
 // Task is an abstract concept built on top of Go routines and
 // channels. Tasks themselves are expected to co-operate and comply with
 // the TaskCtl commands.
 
 type task struct {
-	mutex *sync.Mutex
-
-	this     taskRef      // Refence to task entry in the TaskCtl's task list.
-	name     string       // Free form name.
-	priority Command      // Current priority.
-	cmdCh    chan Command // Channel to receive commands from TaskCtl.
-	statusCh chan status  // Channel to send completion status and error (if any) to TaskCtl.
-	closeCh  chan taskRef // Channel to notify the TaskCtl about ending this task.
+	mutex    *sync.Mutex // Protects the fields below the channels.
+	cmdMutex *sync.Mutex // Serializes commands sent to the task.
+	// saveMutex serializes writes of the record of a durable task.
+	saveMutex *sync.Mutex
+
+	this     taskRef            // Refence to task entry in the TaskCtl's task list.
+	ctl      *TaskCtl           // Owner of the task.
+	id       TaskID             // Unique id within the TaskCtl.
+	name     string             // Free form name.
+	ctx      context.Context    // Cancelled on abort.
+	cancel   context.CancelFunc // Cancels ctx.
+	cmdCh    chan Command       // Channel to receive commands from TaskCtl.
+	statusCh chan status        // Channel to send completion status and error (if any) to TaskCtl.
+	doneCh   chan struct{}      // Closed when the task ends.
+
+	state    State   // Current state.
+	priority Command // Current priority.
+	done     int64   // Reported progress.
+	total    int64
+	lastErr  *probe.Error
+	closed   bool
+
+	// superSuspended is set on tasks suspended because another task
+	// runs at CmdPrioritySuper, they are resumed once it is done.
+	superSuspended bool
+
+	// Durable tasks only, see TaskCtl.StartTask.
+	kind       string // Kind of task, selects its Runner.
+	spec       []byte // Description the task was started with.
+	checkpoint []byte // Last state saved by the task.
 }
 
-// NewTask creates a new task structure and returns a handle to
-// it. Only the task controller has access to the task structure. The
-// caller routine only receives a handle to its task structure. Task
-// handle is like a reference to task self. Caller is expected to listen
-// for commands from the task controller and comply with it co-operatively.
-// this: Task reference is unique identifier assigned by the TaskCtl.
+// newTask creates a new task structure. Only the task controller has
+// access to the task structure, the caller routine only receives a
+// handle to it.
 // name: Free form name of the task. Eg. "Late Night Disk Scrubber".
-func newTask(name string) task {
-	return task{
+func newTask(ctx context.Context, ctl *TaskCtl, id TaskID, name string) *task {
+	ctx, cancel := context.WithCancel(ctx)
+	return &task{
 		// this: Is set by the TaskCtl's NewTask function.
-		mutex:    &sync.Mutex{},
-		name:     name,
-		priority: CmdPriorityMedium,
-		cmdCh:    make(chan Command),
-		statusCh: make(chan status),
-		closeCh:  make(chan taskRef),
+		mutex:     &sync.Mutex{},
+		cmdMutex:  &sync.Mutex{},
+		saveMutex: &sync.Mutex{},
+		ctl:       ctl,
+		id:        id,
+		name:      name,
+		ctx:       ctx,
+		cancel:    cancel,
+		priority:  CmdPriorityMedium,
+		cmdCh:     make(chan Command),
+		// Buffered, tasks never block acknowledging a command.
+		statusCh: make(chan status, 1),
+		doneCh:   make(chan struct{}),
 	}
 }
 
 // getHandle returns a handle to the task. Handle has limited access to the task structure and it is safe to be exposed.
-func (t task) getHandle() Handle {
+func (t *task) getHandle() Handle {
+	return Handle{t: t}
+}
+
+// info returns a snapshot of the task description.
+func (t *task) info() TaskInfo {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
+	return TaskInfo{
+		ID:        t.id,
+		Name:      t.name,
+		State:     t.state,
+		Priority:  t.priority,
+		Done:      t.done,
+		Total:     t.total,
+		LastError: t.lastErr,
+	}
+}
 
-	// Make a handle with limited access to channels (only send or receive).
-	return Handle{
-		cmdCh:    t.cmdCh,
-		statusCh: t.statusCh,
-		closeCh:  t.closeCh,
+// command method sends a command code to the task and returns its
+// completion status.  It fails with ErrTimeout if the task does not
+// take and acknowledge the command before deadline.
+func (t *task) command(cmd Command, deadline time.Time) *probe.Error {
+	err := t.send(cmd, deadline)
+	if err != nil && err.ToGoError() != ErrTaskClosed {
+		t.setError(err)
 	}
+	return err
 }
 
-// command method sends a command code to the task and returns its completion status.
-func (t task) command(cmd Command) status {
+// setError records the last error of the task.
+func (t *task) setError(err *probe.Error) {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
+	t.lastErr = err
+}
+
+// send delivers cmd to the task and waits for its acknowledgement.
+func (t *task) send(cmd Command, deadline time.Time) *probe.Error {
+	t.cmdMutex.Lock()
+	defer t.cmdMutex.Unlock()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
 
-	t.cmdCh <- cmd
-	return <-t.statusCh
+	// Drop a late acknowledgement of a previous command which timed out.
+	select {
+	case <-t.statusCh:
+	default:
+	}
+
+	select {
+	case t.cmdCh <- cmd:
+	case <-t.doneCh:
+		return probe.NewError(ErrTaskClosed)
+	case <-timer.C:
+		return probe.NewError(ErrTimeout)
+	}
+
+	select {
+	case st := <-t.statusCh:
+		switch st.code {
+		case statusDone:
+			return nil
+		case statusBusy:
+			return probe.NewError(ErrTaskBusy)
+		default:
+			if st.err == nil {
+				return probe.NewError(ErrTaskFailed)
+			}
+			return st.err.Trace(t.name)
+		}
+	case <-t.doneCh:
+		return probe.NewError(ErrTaskClosed)
+	case <-timer.C:
+		return probe.NewError(ErrTimeout)
+	}
 }
 
 // close releases all the resources held by this task.
-func (t task) close() {
+func (t *task) close() {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 
 	// Task can be ended in 2 ways.
 	// 1) Calling application invokes Handle.Close().
-	// 2) TaskCtl.Shutdown() ending the task's life.
-	// In either case, task.close() is invoked only via the
-	// TaskCtl. Handle.Close() only sends a message to the TaskCtl to
-	// initiate a close call.
-
-	close(t.cmdCh)
-	close(t.statusCh)
-	close(t.closeCh)
+	// 2) TaskCtl.Abort() cancels its context and the task closes
+	//    its handle in turn.
+	if t.closed {
+		return
+	}
+	t.closed = true
+	t.cancel()
+	close(t.doneCh)
 }
@@ -18,147 +18,343 @@ package tasker
 
 import (
 	"container/list"
+	"context"
+	"errors"
 	"sync"
+	"time"
+
+	"github.com/minio/minio-xl/pkg/probe"
+)
+
+var (
+	// ErrTimeout - task did not acknowledge a command in time.
+	ErrTimeout = errors.New("Timed out waiting for task")
+	// ErrTaskClosed - task ended before acknowledging a command.
+	ErrTaskClosed = errors.New("Task closed")
+	// ErrTaskBusy - task rejected a command with StatusBusy.
+	ErrTaskBusy = errors.New("Task busy")
+	// ErrTaskFailed - task rejected a command with StatusFail.
+	ErrTaskFailed = errors.New("Task failed")
+	// ErrTaskNotFound - no task with the given id.
+	ErrTaskNotFound = errors.New("Task not found")
+	// ErrInvalidPriority - command is not one of CmdPriority*.
+	ErrInvalidPriority = errors.New("Invalid task priority")
+	// ErrShutdown - task controller was shut down.
+	ErrShutdown = errors.New("Task controller shut down")
 )
 
+// superResumeTimeout bounds resuming the tasks suspended by a
+// CmdPrioritySuper task once it is done.
+const superResumeTimeout = 10 * time.Second
+
 // TaskCtl (Task Controller) is a framework to create and manage
 // tasks.
 type TaskCtl struct {
 	mutex *sync.Mutex // Lock
+	name  string
 	// List of tasks managed by this task controller.
-	tasks *list.List
+	tasks    *list.List
+	nextID   TaskID
+	shutdown bool
+
+	// Durable tasks, see NewDurable.
+	dir     string
+	runners map[string]Runner
 }
 
 // New creates a new TaskCtl to create and control a collection of tasks.
 // Single application can create multiple task controllers to manage different set of tasks separately.
 func New(name string) *TaskCtl {
 	return &TaskCtl{
-		mutex: &sync.Mutex{},
-		tasks: list.New(),
+		mutex:   &sync.Mutex{},
+		name:    name,
+		tasks:   list.New(),
+		runners: make(map[string]Runner),
 	}
 }
 
 // NewTask creates a new task structure and returns a handle to it. Only the task controller
 // has access to the task structure. The caller routine only receives a handle to its task structure.
 // Task handle is like a reference to task self. Caller is expected to listen for commands from
-// the task controller and comply with it co-operatively.
-func (tc *TaskCtl) NewTask(name string) Handle {
+// the task controller and comply with it co-operatively.  The context of the task is derived from
+// ctx.
+func (tc *TaskCtl) NewTask(ctx context.Context, name string) (Handle, *probe.Error) {
 	tc.mutex.Lock()
 	defer tc.mutex.Unlock()
 
-	// Create a new task.
-	tsk := newTask(name)
+	if tc.shutdown {
+		return Handle{}, probe.NewError(ErrShutdown)
+	}
 
-	// Register this task in the TaskCtl's tasklist and save the reference.
+	// Create a new task and register it in the TaskCtl's tasklist.
+	tc.nextID++
+	tsk := newTask(ctx, tc, tc.nextID, name)
 	tsk.this = tc.tasks.PushBack(tsk)
 
-	// Free task from the tasklist upon close call.
-	go func() {
-		// Release the tasks resources upon return of this function.
-		defer tsk.close()
+	// Return a handle to this task.
+	return tsk.getHandle(), nil
+}
 
-		// Will be notified here upon task's end of life.
-		this := <-tsk.closeCh
+// remove releases the task from the task list upon Handle.Close().
+func (tc *TaskCtl) remove(t *task) {
+	// A task whose parent context was cancelled is interrupted, it did
+	// not finish its job.
+	t.mutex.Lock()
+	finished := t.state == StateAborting || t.ctx.Err() == nil
+	t.mutex.Unlock()
 
-		tc.mutex.Lock()
-		defer tc.mutex.Unlock()
+	t.close()
+	if t.kind != "" {
+		tc.forget(t, finished)
+	}
 
-		// Release the task structure from the task list.
-		tc.tasks.Remove(this)
-	}()
+	tc.mutex.Lock()
+	if t.this != nil {
+		tc.tasks.Remove(t.this)
+		t.this = nil
+	}
+	tc.mutex.Unlock()
 
-	// Return a handle to this task.
-	return tsk.getHandle()
+	t.mutex.Lock()
+	super := t.priority == CmdPrioritySuper
+	t.mutex.Unlock()
+	if super {
+		// Resume in background, Close must not block the task.
+		go tc.superResume(time.Now().Add(superResumeTimeout))
+	}
 }
 
-// Shutdown ends all tasks, including the suspended ones.
-func (tc *TaskCtl) Shutdown() {
+// snapshot returns the tasks currently in the task list.
+func (tc *TaskCtl) snapshot() []*task {
 	tc.mutex.Lock()
 	defer tc.mutex.Unlock()
 
-	var wg sync.WaitGroup
-
-	// End all tasks.
+	var tasks []*task
 	for e := tc.tasks.Front(); e != nil; e = e.Next() {
-		wg.Add(1)
-		thisTask := e.Value.(task) // Make a local copy for go routine.
-		// End tasks in background. Flow of events from here is as follows: thisTask.handle.Close() -> tc.NewTask() -> this.task.close().
-		go func() {
-			thisTask.getHandle().Close()
-			wg.Done()
-		}()
+		tasks = append(tasks, e.Value.(*task))
 	}
+	return tasks
+}
 
-	wg.Wait() // Wait for all tasks to end gracefully.
+// find returns the task with the given id.
+func (tc *TaskCtl) find(id TaskID) (*task, *probe.Error) {
+	for _, t := range tc.snapshot() {
+		if t.id == id {
+			return t, nil
+		}
+	}
+	return nil, probe.NewError(ErrTaskNotFound)
+}
 
-	// Reset the task pool.
-	tc.tasks = nil
+// List returns the description of all tasks in creation order.
+func (tc *TaskCtl) List() []TaskInfo {
+	var infos []TaskInfo
+	for _, t := range tc.snapshot() {
+		infos = append(infos, t.info())
+	}
+	return infos
 }
 
-// Suspend puts all tasks to sleep.
-func (tc *TaskCtl) Suspend() bool {
-	tc.mutex.Lock()
-	defer tc.mutex.Unlock()
+// Info returns the description of the task with the given id.
+func (tc *TaskCtl) Info(id TaskID) (TaskInfo, *probe.Error) {
+	t, err := tc.find(id)
+	if err != nil {
+		return TaskInfo{}, err.Trace()
+	}
+	return t.info(), nil
+}
 
+// forAll runs fn on all tasks in parallel and returns the first error.
+func forAll(tasks []*task, fn func(*task) *probe.Error) *probe.Error {
 	var wg sync.WaitGroup
-
-	// If any one of the task fails to suspend, this flag will set to false.
-	statusAll := make([]status, tc.tasks.Len())
-
-	// Suspend all tasks.
-	i := 0
-	for e := tc.tasks.Front(); e != nil; e = e.Next() {
+	errs := make([]*probe.Error, len(tasks))
+	for i, t := range tasks {
 		wg.Add(1)
-		locTask := e.Value.(task) // Make a local copy for go routine.
-		locI := i                 // local i
-		// Suspend a task in background.
-		go func(locI int) {
+		go func(i int, t *task) {
 			defer wg.Done()
-			statusAll[locI] = locTask.command(CmdSignalSuspend)
-		}(locI)
-		i++
+			errs[i] = fn(t)
+		}(i, t)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err.Trace()
+		}
 	}
+	return nil
+}
 
-	wg.Wait() // Wait for all tasks to suspend gracefully.
+// suspend puts a running task to sleep.
+func suspend(t *task, deadline time.Time) *probe.Error {
+	t.mutex.Lock()
+	state := t.state
+	t.mutex.Unlock()
+	if state != StateRunning {
+		return nil
+	}
+	if err := t.command(CmdSignalSuspend, deadline); err != nil {
+		return err.Trace(t.name)
+	}
+	t.mutex.Lock()
+	t.state = StateSuspended
+	t.mutex.Unlock()
+	return nil
+}
 
-	for _, st := range statusAll {
-		if st.code != statusDone {
-			return false
-		}
+// resume wakes up a suspended task.
+func resume(t *task, deadline time.Time) *probe.Error {
+	t.mutex.Lock()
+	state := t.state
+	t.mutex.Unlock()
+	if state != StateSuspended {
+		return nil
+	}
+	if err := t.command(CmdSignalResume, deadline); err != nil {
+		return err.Trace(t.name)
 	}
-	return true
+	t.mutex.Lock()
+	t.state = StateRunning
+	t.superSuspended = false
+	t.mutex.Unlock()
+	return nil
 }
 
-// Resume wakes up all suspended task from sleep.
-func (tc *TaskCtl) Resume() bool {
-	tc.mutex.Lock()
-	defer tc.mutex.Unlock()
+// abort cancels the context of the task, sends it CmdSignalAbort and
+// waits for it to close.
+func abort(t *task, deadline time.Time) *probe.Error {
+	t.mutex.Lock()
+	t.state = StateAborting
+	t.mutex.Unlock()
+	t.cancel()
 
-	var wg sync.WaitGroup
+	if err := t.command(CmdSignalAbort, deadline); err != nil && err.ToGoError() != ErrTaskClosed {
+		return err.Trace(t.name)
+	}
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case <-t.doneCh:
+		return nil
+	case <-timer.C:
+		return probe.NewError(ErrTimeout).Trace(t.name)
+	}
+}
 
-	// If any one of the task fails to suspend, this flag will set to false.
-	statusAll := make([]status, tc.tasks.Len())
+// Suspend puts the task to sleep, it fails if the task does not
+// acknowledge within timeout.
+func (tc *TaskCtl) Suspend(id TaskID, timeout time.Duration) *probe.Error {
+	t, err := tc.find(id)
+	if err != nil {
+		return err.Trace()
+	}
+	return suspend(t, time.Now().Add(timeout))
+}
 
-	i := 0
-	// Resume all suspended tasks.
-	for e := tc.tasks.Front(); e != nil; e = e.Next() {
-		wg.Add(1)
-		locTask := e.Value.(task) // Make a local copy for go routine.
-		locI := i                 // local i
-		// Resume a task in background.
-		go func(locI int) {
-			defer wg.Done()
-			statusAll[locI] = locTask.command(CmdSignalResume)
-		}(locI)
-		i++
+// SuspendAll puts all tasks to sleep.
+func (tc *TaskCtl) SuspendAll(timeout time.Duration) *probe.Error {
+	deadline := time.Now().Add(timeout)
+	return forAll(tc.snapshot(), func(t *task) *probe.Error { return suspend(t, deadline) })
+}
+
+// Resume wakes up the suspended task.
+func (tc *TaskCtl) Resume(id TaskID, timeout time.Duration) *probe.Error {
+	t, err := tc.find(id)
+	if err != nil {
+		return err.Trace()
+	}
+	return resume(t, time.Now().Add(timeout))
+}
+
+// ResumeAll wakes up all suspended task from sleep.
+func (tc *TaskCtl) ResumeAll(timeout time.Duration) *probe.Error {
+	deadline := time.Now().Add(timeout)
+	return forAll(tc.snapshot(), func(t *task) *probe.Error { return resume(t, deadline) })
+}
+
+// Abort ends the task, it fails if the task does not close within
+// timeout.
+func (tc *TaskCtl) Abort(id TaskID, timeout time.Duration) *probe.Error {
+	t, err := tc.find(id)
+	if err != nil {
+		return err.Trace()
 	}
-	wg.Wait() // Wait for all tasks to resume.
+	return abort(t, time.Now().Add(timeout))
+}
+
+// AbortAll ends all tasks, including the suspended ones.
+func (tc *TaskCtl) AbortAll(timeout time.Duration) *probe.Error {
+	deadline := time.Now().Add(timeout)
+	return forAll(tc.snapshot(), func(t *task) *probe.Error { return abort(t, deadline) })
+}
+
+// SetPriority delivers one of the CmdPriority* commands to the task.
+// Tasks with a lower priority are suspended while a task runs at
+// CmdPrioritySuper, and resumed once it lowers its priority or ends.
+func (tc *TaskCtl) SetPriority(id TaskID, priority Command, timeout time.Duration) *probe.Error {
+	if !priority.IsPriority() {
+		return probe.NewError(ErrInvalidPriority)
+	}
+	t, err := tc.find(id)
+	if err != nil {
+		return err.Trace()
+	}
+	deadline := time.Now().Add(timeout)
+	if err = t.command(priority, deadline); err != nil {
+		return err.Trace(t.name)
+	}
+	t.mutex.Lock()
+	previous := t.priority
+	t.priority = priority
+	t.mutex.Unlock()
+
+	switch {
+	case priority == CmdPrioritySuper && previous != CmdPrioritySuper:
+		return tc.superSuspend(t, deadline)
+	case previous == CmdPrioritySuper && priority != CmdPrioritySuper:
+		return tc.superResume(deadline)
+	}
+	return nil
+}
 
-	for _, st := range statusAll {
-		if st.code != statusDone {
-			return false
+// superSuspend suspends all running tasks below CmdPrioritySuper.
+func (tc *TaskCtl) superSuspend(super *task, deadline time.Time) *probe.Error {
+	var tasks []*task
+	for _, t := range tc.snapshot() {
+		t.mutex.Lock()
+		if t != super && t.priority != CmdPrioritySuper && t.state == StateRunning {
+			t.superSuspended = true
+			tasks = append(tasks, t)
 		}
+		t.mutex.Unlock()
 	}
-	return true
+	return forAll(tasks, func(t *task) *probe.Error { return suspend(t, deadline) })
+}
+
+// superResume resumes the tasks suspended by superSuspend once no task
+// runs at CmdPrioritySuper anymore.
+func (tc *TaskCtl) superResume(deadline time.Time) *probe.Error {
+	var tasks []*task
+	for _, t := range tc.snapshot() {
+		t.mutex.Lock()
+		super := t.priority == CmdPrioritySuper && !t.closed
+		suspended := t.superSuspended
+		t.mutex.Unlock()
+		if super {
+			return nil
+		}
+		if suspended {
+			tasks = append(tasks, t)
+		}
+	}
+	return forAll(tasks, func(t *task) *probe.Error { return resume(t, deadline) })
+}
+
+// Shutdown ends all tasks, including the suspended ones, no new task can
+// be created afterwards.
+func (tc *TaskCtl) Shutdown(timeout time.Duration) *probe.Error {
+	tc.mutex.Lock()
+	tc.shutdown = true
+	tc.mutex.Unlock()
 
+	return tc.AbortAll(timeout)
 }
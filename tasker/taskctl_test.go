@@ -18,9 +18,12 @@
 package tasker_test
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
-	"github.com/minio/minio/pkg/tasker"
+	"github.com/minio/minio-xl/pkg/tasker"
 
 	. "gopkg.in/check.v1"
 )
@@ -31,8 +34,216 @@ type MySuite struct{}
 
 var _ = Suite(&MySuite{})
 
-func (s *MySuite) TestCheckData(c *C) {
-	testTasks := tasker.New("Test Task")
-	testTasks.Shutdown()
-	// c.Assert(err, Not(IsNil))
+const timeout = 5 * time.Second
+
+// worker is a co-operative task recording the priorities it receives.
+type worker struct {
+	mutex      *sync.Mutex
+	priorities []tasker.Command
+	done       chan struct{}
+}
+
+func startWorker(c *C, tc *tasker.TaskCtl, name string) (tasker.TaskID, *worker) {
+	h, err := tc.NewTask(context.Background(), name)
+	c.Assert(err, IsNil)
+	w := &worker{mutex: &sync.Mutex{}, done: make(chan struct{})}
+	go func() {
+		defer close(w.done)
+		defer h.Close()
+		var units int64
+		tick := time.NewTicker(time.Millisecond)
+		defer tick.Stop()
+		suspended := false
+		for {
+			select {
+			case cmd := <-h.Listen():
+				switch cmd {
+				case tasker.CmdSignalSuspend:
+					suspended = true
+				case tasker.CmdSignalResume:
+					suspended = false
+				case tasker.CmdSignalAbort:
+					h.StatusDone()
+					return
+				default:
+					w.mutex.Lock()
+					w.priorities = append(w.priorities, cmd)
+					w.mutex.Unlock()
+				}
+				h.StatusDone()
+			case <-h.Context().Done():
+				return
+			case <-tick.C:
+				if !suspended {
+					units++
+					h.Progress(units, 1000)
+				}
+			}
+		}
+	}()
+	return h.ID(), w
+}
+
+func find(tc *tasker.TaskCtl, id tasker.TaskID) (tasker.TaskInfo, bool) {
+	for _, info := range tc.List() {
+		if info.ID == id {
+			return info, true
+		}
+	}
+	return tasker.TaskInfo{}, false
+}
+
+func (s *MySuite) TestList(c *C) {
+	tc := tasker.New("Test Tasks")
+	id1, _ := startWorker(c, tc, "scrubber")
+	id2, _ := startWorker(c, tc, "healer")
+
+	infos := tc.List()
+	c.Assert(infos, HasLen, 2)
+	c.Assert(infos[0].ID, Equals, id1)
+	c.Assert(infos[0].Name, Equals, "scrubber")
+	c.Assert(infos[1].ID, Equals, id2)
+	c.Assert(infos[1].State, Equals, tasker.StateRunning)
+	c.Assert(infos[1].Priority, Equals, tasker.CmdPriorityMedium)
+
+	// Progress is reported by the task.
+	for {
+		if info, _ := find(tc, id1); info.Done > 0 {
+			c.Assert(info.Total, Equals, int64(1000))
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	c.Assert(tc.Shutdown(timeout), IsNil)
+	c.Assert(tc.List(), HasLen, 0)
+}
+
+func (s *MySuite) TestSuspendResume(c *C) {
+	tc := tasker.New("Test Tasks")
+	id1, _ := startWorker(c, tc, "scrubber")
+	id2, _ := startWorker(c, tc, "healer")
+
+	c.Assert(tc.Suspend(id1, timeout), IsNil)
+	info, _ := find(tc, id1)
+	c.Assert(info.State, Equals, tasker.StateSuspended)
+	info, _ = find(tc, id2)
+	c.Assert(info.State, Equals, tasker.StateRunning)
+
+	// No progress while suspended.
+	info, _ = find(tc, id1)
+	time.Sleep(20 * time.Millisecond)
+	again, _ := find(tc, id1)
+	c.Assert(again.Done, Equals, info.Done)
+
+	c.Assert(tc.Resume(id1, timeout), IsNil)
+	info, _ = find(tc, id1)
+	c.Assert(info.State, Equals, tasker.StateRunning)
+
+	c.Assert(tc.SuspendAll(timeout), IsNil)
+	for _, info := range tc.List() {
+		c.Assert(info.State, Equals, tasker.StateSuspended)
+	}
+	c.Assert(tc.ResumeAll(timeout), IsNil)
+	for _, info := range tc.List() {
+		c.Assert(info.State, Equals, tasker.StateRunning)
+	}
+
+	err := tc.Suspend(tasker.TaskID(1000), timeout)
+	c.Assert(err, NotNil)
+	c.Assert(err.ToGoError(), Equals, tasker.ErrTaskNotFound)
+
+	c.Assert(tc.Shutdown(timeout), IsNil)
+}
+
+func (s *MySuite) TestAbort(c *C) {
+	tc := tasker.New("Test Tasks")
+	id1, w1 := startWorker(c, tc, "scrubber")
+	_, w2 := startWorker(c, tc, "healer")
+	_, w3 := startWorker(c, tc, "rebalancer")
+
+	c.Assert(tc.Abort(id1, timeout), IsNil)
+	<-w1.done
+	_, ok := find(tc, id1)
+	c.Assert(ok, Equals, false)
+	c.Assert(tc.List(), HasLen, 2)
+
+	// Suspended tasks are aborted too.
+	c.Assert(tc.SuspendAll(timeout), IsNil)
+	c.Assert(tc.AbortAll(timeout), IsNil)
+	<-w2.done
+	<-w3.done
+	c.Assert(tc.List(), HasLen, 0)
+}
+
+func (s *MySuite) TestTimeout(c *C) {
+	tc := tasker.New("Test Tasks")
+	// A task which never listens for commands.
+	h, err := tc.NewTask(context.Background(), "stuck")
+	c.Assert(err, IsNil)
+
+	err = tc.Suspend(h.ID(), 10*time.Millisecond)
+	c.Assert(err, NotNil)
+	c.Assert(err.ToGoError(), Equals, tasker.ErrTimeout)
+	info, _ := find(tc, h.ID())
+	c.Assert(info.State, Equals, tasker.StateRunning)
+
+	// Abort cancels the context, but the task does not close.
+	err = tc.Abort(h.ID(), 10*time.Millisecond)
+	c.Assert(err, NotNil)
+	c.Assert(err.ToGoError(), Equals, tasker.ErrTimeout)
+	c.Assert(h.Context().Err(), NotNil)
+	info, _ = find(tc, h.ID())
+	c.Assert(info.State, Equals, tasker.StateAborting)
+
+	h.Close()
+	c.Assert(tc.List(), HasLen, 0)
+}
+
+func (s *MySuite) TestPriority(c *C) {
+	tc := tasker.New("Test Tasks")
+	id1, w1 := startWorker(c, tc, "scrubber")
+	id2, _ := startWorker(c, tc, "healer")
+
+	c.Assert(tc.SetPriority(id1, tasker.CmdPriorityLow, timeout), IsNil)
+	info, _ := find(tc, id1)
+	c.Assert(info.Priority, Equals, tasker.CmdPriorityLow)
+
+	err := tc.SetPriority(id1, tasker.CmdSignalAbort, timeout)
+	c.Assert(err, NotNil)
+	c.Assert(err.ToGoError(), Equals, tasker.ErrInvalidPriority)
+
+	// Other tasks are suspended while a task runs at super priority.
+	c.Assert(tc.SetPriority(id1, tasker.CmdPrioritySuper, timeout), IsNil)
+	info, _ = find(tc, id2)
+	c.Assert(info.State, Equals, tasker.StateSuspended)
+	c.Assert(tc.SetPriority(id1, tasker.CmdPriorityHigh, timeout), IsNil)
+	info, _ = find(tc, id2)
+	c.Assert(info.State, Equals, tasker.StateRunning)
+
+	w1.mutex.Lock()
+	c.Assert(w1.priorities, DeepEquals, []tasker.Command{tasker.CmdPriorityLow, tasker.CmdPrioritySuper, tasker.CmdPriorityHigh})
+	w1.mutex.Unlock()
+
+	// ... and resumed once it ends.
+	c.Assert(tc.SetPriority(id1, tasker.CmdPrioritySuper, timeout), IsNil)
+	info, _ = find(tc, id2)
+	c.Assert(info.State, Equals, tasker.StateSuspended)
+	c.Assert(tc.Abort(id1, timeout), IsNil)
+	for {
+		if info, _ = find(tc, id2); info.State == tasker.StateRunning {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	c.Assert(tc.Shutdown(timeout), IsNil)
+}
+
+func (s *MySuite) TestShutdown(c *C) {
+	tc := tasker.New("Test Tasks")
+	_, w := startWorker(c, tc, "scrubber")
+	c.Assert(tc.Shutdown(timeout), IsNil)
+	<-w.done
+	_, err := tc.NewTask(context.Background(), "late")
+	c.Assert(err, NotNil)
+	c.Assert(err.ToGoError(), Equals, tasker.ErrShutdown)
 }
@@ -0,0 +1,327 @@
+/*
+ * Minio Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package yielder
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// Priority is the class of an I/O stream sharing a Limiter.
+type Priority int
+
+const (
+	// Background I/O such as heal, rebalance and scrubbing only gets the
+	// bandwidth foreground I/O leaves unused.
+	Background Priority = iota
+	// Foreground I/O serving requests is always served first, it
+	// borrows all the bandwidth it needs from background I/O.
+	Foreground
+
+	numPriorities = 2
+)
+
+// ErrExceedsBurst - more bytes requested at once than the bucket holds.
+var ErrExceedsBurst = errors.New("yielder: request exceeds limiter burst")
+
+// waiter is a Wait call queued for tokens.
+type waiter struct {
+	n    int64
+	wake chan struct{} // signalled when the waiter becomes the head
+}
+
+// Limiter is a token bucket limiting the bytes per second of all readers
+// and writers sharing it.  The bucket holds one second worth of tokens,
+// idle time is not saved up beyond that.
+//
+// Waiters are served first in first out within a priority, a waiting
+// Foreground request always goes before Background ones.
+type Limiter struct {
+	mu     sync.Mutex
+	rate   int64   // bytes per second, zero is unlimited
+	tokens float64 // available tokens, at most rate
+	last   time.Time
+	queues [numPriorities]list.List
+}
+
+// NewLimiter returns a Limiter allowing bytesPerSec, zero or less is
+// unlimited.
+func NewLimiter(bytesPerSec int64) *Limiter {
+	l := &Limiter{last: time.Now()}
+	l.SetLimit(bytesPerSec)
+	l.tokens = float64(l.rate)
+	return l
+}
+
+// SetLimit changes the bytes per second at runtime, zero or less is
+// unlimited.  Waiting requests are recomputed with the new limit.
+func (l *Limiter) SetLimit(bytesPerSec int64) {
+	if bytesPerSec < 0 {
+		bytesPerSec = 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	l.rate = bytesPerSec
+	if l.tokens > float64(l.rate) {
+		l.tokens = float64(l.rate)
+	}
+	if l.rate == 0 {
+		// Unlimited, let everybody through.
+		for i := range l.queues {
+			for e := l.queues[i].Front(); e != nil; e = e.Next() {
+				signal(e.Value.(*waiter))
+			}
+		}
+		return
+	}
+	l.wakeHead()
+}
+
+// Limit returns the current bytes per second, zero is unlimited.
+func (l *Limiter) Limit() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate
+}
+
+// Burst returns the largest number of bytes a single Wait can request,
+// zero is unlimited.
+func (l *Limiter) Burst() int64 {
+	return l.Limit()
+}
+
+// refill adds the tokens accumulated since the last refill.
+// Must be called with l.mu held.
+func (l *Limiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * float64(l.rate)
+		if l.tokens > float64(l.rate) {
+			l.tokens = float64(l.rate)
+		}
+	}
+	l.last = now
+}
+
+// head returns the element of the next waiter to serve.
+// Must be called with l.mu held.
+func (l *Limiter) head() *list.Element {
+	for prio := numPriorities - 1; prio >= 0; prio-- {
+		if front := l.queues[prio].Front(); front != nil {
+			return front
+		}
+	}
+	return nil
+}
+
+// wakeHead signals the next waiter to serve, it computes its own delay.
+// Must be called with l.mu held.
+func (l *Limiter) wakeHead() {
+	if head := l.head(); head != nil {
+		signal(head.Value.(*waiter))
+	}
+}
+
+func signal(w *waiter) {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// queuedAhead reports whether requests of prio or a higher priority are
+// waiting.  Must be called with l.mu held.
+func (l *Limiter) queuedAhead(prio Priority) bool {
+	for p := int(prio); p < numPriorities; p++ {
+		if l.queues[p].Len() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Wait blocks until n bytes may be transferred at priority prio, or ctx
+// is done.  n must not exceed Burst.
+func (l *Limiter) Wait(ctx context.Context, prio Priority, n int64) error {
+	if prio < Background {
+		prio = Background
+	}
+	if prio > Foreground {
+		prio = Foreground
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	if l.rate == 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	if n > l.rate {
+		l.mu.Unlock()
+		return ErrExceedsBurst
+	}
+	l.refill(time.Now())
+	if !l.queuedAhead(prio) && l.tokens >= float64(n) {
+		l.tokens -= float64(n)
+		l.mu.Unlock()
+		return nil
+	}
+
+	w := &waiter{n: n, wake: make(chan struct{}, 1)}
+	elem := l.queues[prio].PushBack(w)
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		var delay <-chan time.Time
+		if l.rate == 0 {
+			l.queues[prio].Remove(elem)
+			l.wakeHead()
+			l.mu.Unlock()
+			return nil
+		}
+		if l.head() == elem {
+			l.refill(time.Now())
+			if n > l.rate {
+				// The limit was lowered while waiting.
+				l.queues[prio].Remove(elem)
+				l.wakeHead()
+				l.mu.Unlock()
+				return ErrExceedsBurst
+			}
+			if l.tokens >= float64(n) {
+				l.tokens -= float64(n)
+				l.queues[prio].Remove(elem)
+				l.wakeHead()
+				l.mu.Unlock()
+				return nil
+			}
+			d := time.Duration((float64(n) - l.tokens) / float64(l.rate) * float64(time.Second))
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(d)
+			delay = timer.C
+		}
+		l.mu.Unlock()
+
+		select {
+		case <-w.wake:
+		case <-delay:
+		case <-ctx.Done():
+			l.mu.Lock()
+			wasHead := l.head() == elem
+			l.queues[prio].Remove(elem)
+			if wasHead {
+				l.wakeHead()
+			}
+			l.mu.Unlock()
+			return ctx.Err()
+		}
+		l.mu.Lock()
+	}
+}
+
+// waitAll waits for n bytes in chunks of at most Burst bytes, the limit
+// may change in between.
+func (l *Limiter) waitAll(ctx context.Context, prio Priority, n int64) error {
+	for n > 0 {
+		chunk := n
+		if burst := l.Burst(); burst > 0 && chunk > burst {
+			chunk = burst
+		}
+		err := l.Wait(ctx, prio, chunk)
+		if err == ErrExceedsBurst {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+// LimitedReader reads from an io.Reader at the rate of a Limiter.
+type LimitedReader struct {
+	ctx      context.Context
+	reader   io.Reader
+	limiter  *Limiter
+	priority Priority
+}
+
+// NewLimitedReader returns a reader limited by limiter at priority, reads
+// fail with ctx.Err() once ctx is done.
+func NewLimitedReader(ctx context.Context, reader io.Reader, limiter *Limiter, priority Priority) *LimitedReader {
+	return &LimitedReader{ctx, reader, limiter, priority}
+}
+
+// Read reads at most Burst bytes and waits until they fit into the limit.
+func (r *LimitedReader) Read(p []byte) (n int, err error) {
+	if burst := r.limiter.Burst(); burst > 0 && int64(len(p)) > burst {
+		p = p[:burst]
+	}
+	n, err = r.reader.Read(p)
+	if werr := r.limiter.waitAll(r.ctx, r.priority, int64(n)); werr != nil {
+		return n, werr
+	}
+	return n, err
+}
+
+// LimitedWriter writes to an io.Writer at the rate of a Limiter.
+type LimitedWriter struct {
+	ctx      context.Context
+	writer   io.Writer
+	limiter  *Limiter
+	priority Priority
+}
+
+// NewLimitedWriter returns a writer limited by limiter at priority, writes
+// fail with ctx.Err() once ctx is done.
+func NewLimitedWriter(ctx context.Context, writer io.Writer, limiter *Limiter, priority Priority) *LimitedWriter {
+	return &LimitedWriter{ctx, writer, limiter, priority}
+}
+
+// Write writes p in chunks of at most Burst bytes, waiting for each chunk
+// to fit into the limit before writing it.
+func (w *LimitedWriter) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		chunk := p
+		if burst := w.limiter.Burst(); burst > 0 && int64(len(chunk)) > burst {
+			chunk = chunk[:burst]
+		}
+		if err = w.limiter.Wait(w.ctx, w.priority, int64(len(chunk))); err != nil {
+			return n, err
+		}
+		m, err := w.writer.Write(chunk)
+		n += m
+		if err != nil {
+			return n, err
+		}
+		p = p[m:]
+	}
+	return n, nil
+}
@@ -15,7 +15,11 @@
  */
 
 // Package yielder relinquishes control to other go routines more
-// frequently in the middle of busy I/O activity
+// frequently in the middle of busy I/O activity.
+//
+// Limiter, LimitedReader and LimitedWriter go further and cap the
+// bandwidth of I/O streams, several streams share one Limiter.  Streams
+// have a Priority, background I/O only gets what foreground I/O leaves.
 package yielder
 
 import (
@@ -18,8 +18,12 @@ package yielder
 
 import (
 	"bytes"
+	"context"
+	"io"
 	"io/ioutil"
+	"sync"
 	"testing"
+	"time"
 
 	. "github.com/minio/check"
 )
@@ -39,3 +43,106 @@ func (s *MySuite) TestYielder(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(n, Equals, 5)
 }
+
+func (s *MySuite) TestLimitedReader(c *C) {
+	const rate = 1 << 20
+	data := make([]byte, rate+rate/4)
+	limiter := NewLimiter(rate)
+	start := time.Now()
+	r := NewLimitedReader(context.Background(), bytes.NewReader(data), limiter, Background)
+	n, err := io.Copy(ioutil.Discard, r)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, int64(len(data)))
+	// The first second worth of data is in the bucket already.
+	elapsed := time.Since(start)
+	c.Assert(elapsed > 200*time.Millisecond, Equals, true, Commentf("%v", elapsed))
+	c.Assert(elapsed < 2*time.Second, Equals, true, Commentf("%v", elapsed))
+}
+
+func (s *MySuite) TestLimitedWriterShared(c *C) {
+	const rate = 1 << 20
+	limiter := NewLimiter(rate)
+	// Drain the bucket.
+	c.Assert(limiter.Wait(context.Background(), Foreground, rate), IsNil)
+
+	// Two writers of rate/4 each share the limiter, together they need
+	// about half a second.
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w := NewLimitedWriter(context.Background(), ioutil.Discard, limiter, Foreground)
+			n, err := w.Write(make([]byte, rate/4))
+			c.Check(err, IsNil)
+			c.Check(n, Equals, rate/4)
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	c.Assert(elapsed > 400*time.Millisecond, Equals, true, Commentf("%v", elapsed))
+	c.Assert(elapsed < 2*time.Second, Equals, true, Commentf("%v", elapsed))
+}
+
+func (s *MySuite) TestLimiterSetLimit(c *C) {
+	limiter := NewLimiter(1000)
+	c.Assert(limiter.Wait(context.Background(), Background, 1000), IsNil)
+	c.Assert(limiter.Wait(context.Background(), Background, 1001), Equals, ErrExceedsBurst)
+
+	// Waiting for a second at this rate, raising the limit wakes it up.
+	done := make(chan error)
+	start := time.Now()
+	go func() { done <- limiter.Wait(context.Background(), Background, 1000) }()
+	time.Sleep(10 * time.Millisecond)
+	limiter.SetLimit(0)
+	c.Assert(<-done, IsNil)
+	c.Assert(time.Since(start) < 500*time.Millisecond, Equals, true)
+	c.Assert(limiter.Limit(), Equals, int64(0))
+
+	// Unlimited readers read in one go.
+	r := NewLimitedReader(context.Background(), bytes.NewReader(make([]byte, 1<<20)), limiter, Background)
+	p := make([]byte, 1<<20)
+	n, err := r.Read(p)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 1<<20)
+}
+
+func (s *MySuite) TestLimiterCancel(c *C) {
+	limiter := NewLimiter(1000)
+	c.Assert(limiter.Wait(context.Background(), Background, 1000), IsNil)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	c.Assert(limiter.Wait(ctx, Background, 1000), Equals, context.DeadlineExceeded)
+
+	w := NewLimitedWriter(ctx, ioutil.Discard, limiter, Foreground)
+	_, err := w.Write([]byte("x"))
+	c.Assert(err, Equals, context.DeadlineExceeded)
+}
+
+// A foreground stream is served before a background one which started
+// waiting earlier.
+func (s *MySuite) TestLimiterPriority(c *C) {
+	const rate = 100 * 1000
+	limiter := NewLimiter(rate)
+	c.Assert(limiter.Wait(context.Background(), Background, rate), IsNil)
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	wait := func(prio Priority) {
+		defer wg.Done()
+		c.Check(limiter.Wait(context.Background(), prio, rate/4), IsNil)
+		mu.Lock()
+		order = append(order, prio)
+		mu.Unlock()
+	}
+	wg.Add(3)
+	go wait(Background)
+	time.Sleep(20 * time.Millisecond)
+	go wait(Background)
+	time.Sleep(20 * time.Millisecond)
+	go wait(Foreground)
+	wg.Wait()
+	c.Assert(order, DeepEquals, []Priority{Foreground, Background, Background})
+}